@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+type AttachmentListOptions struct {
+	Parent string
+	Limit  int
+	Offset string
+}
+
+func (c *HTTPClient) ListAttachments(ctx context.Context, opts AttachmentListOptions) (*models.ListResponse[models.Attachment], error) {
+	if opts.Parent == "" {
+		return nil, fmt.Errorf("parent is required")
+	}
+
+	params := url.Values{}
+	params.Set("parent", opts.Parent)
+	params.Set("opt_fields", "name,resource_subtype,created_at,size,host,view_url,permanent_url")
+	if opts.Limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+	}
+	if opts.Offset != "" {
+		params.Set("offset", opts.Offset)
+	}
+
+	var response struct {
+		Data     []models.Attachment `json:"data"`
+		NextPage *models.PageInfo    `json:"next_page,omitempty"`
+	}
+
+	if err := c.get(ctx, "/attachments?"+params.Encode(), &response); err != nil {
+		return nil, err
+	}
+
+	return &models.ListResponse[models.Attachment]{
+		Data:     response.Data,
+		NextPage: response.NextPage,
+	}, nil
+}
+
+func (c *HTTPClient) GetAttachment(ctx context.Context, gid string) (*models.Attachment, error) {
+	var response struct {
+		Data models.Attachment `json:"data"`
+	}
+
+	if err := c.get(ctx, "/attachments/"+gid, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+type AttachmentUploadRequest struct {
+	Parent  string
+	Name    string
+	Content io.Reader
+}
+
+func (c *HTTPClient) UploadAttachment(ctx context.Context, req AttachmentUploadRequest) (*models.Attachment, error) {
+	if req.Parent == "" {
+		return nil, fmt.Errorf("parent is required")
+	}
+	if req.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	var response struct {
+		Data models.Attachment `json:"data"`
+	}
+
+	fields := map[string]string{"parent": req.Parent}
+	file := &multipartFile{FieldName: "file", FileName: req.Name, Content: req.Content}
+	if err := c.doMultipart(ctx, "/attachments", fields, file, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+type AttachmentURLRequest struct {
+	Parent string
+	URL    string
+	Name   string
+}
+
+func (c *HTTPClient) AttachURL(ctx context.Context, req AttachmentURLRequest) (*models.Attachment, error) {
+	if req.Parent == "" {
+		return nil, fmt.Errorf("parent is required")
+	}
+	if req.URL == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+
+	fields := map[string]string{
+		"parent":           req.Parent,
+		"resource_subtype": "external",
+		"url":              req.URL,
+	}
+	if req.Name != "" {
+		fields["name"] = req.Name
+	} else {
+		fields["name"] = req.URL
+	}
+
+	var response struct {
+		Data models.Attachment `json:"data"`
+	}
+
+	if err := c.doMultipart(ctx, "/attachments", fields, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+func (c *HTTPClient) DeleteAttachment(ctx context.Context, gid string) error {
+	return c.delete(ctx, "/attachments/"+gid)
+}
+
+// DownloadAttachment streams an attachment's download_url to w. Download URLs
+// are pre-signed, so no Authorization header is sent.
+func (c *HTTPClient) DownloadAttachment(ctx context.Context, downloadURL string, w io.Writer) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return 0, errors.NewGeneralError("failed to create request", err)
+	}
+
+	if c.debug && c.debugOut != nil {
+		_, _ = fmt.Fprintf(c.debugOut, "[DEBUG] GET %s\n", truncateBody(downloadURL))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, errors.NewNetworkError("download failed", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, errors.NewGeneralError(fmt.Sprintf("download failed: %s", resp.Status), nil)
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, errors.NewNetworkError("failed to read download", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/config"
+)
+
+func TestUploadAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/attachments" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data; boundary=") {
+			t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+		}
+
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm() error = %v", err)
+		}
+		if got := r.FormValue("parent"); got != "123" {
+			t.Errorf("parent = %q, want 123", got)
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile() error = %v", err)
+		}
+		defer func() { _ = file.Close() }()
+		content, _ := io.ReadAll(file)
+
+		if header.Filename != "test.log" {
+			t.Errorf("filename = %q, want test.log", header.Filename)
+		}
+		if string(content) != "PASS\n" {
+			t.Errorf("content = %q", content)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"gid": "a1", "name": header.Filename, "resource_subtype": "asana"},
+		})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL))
+
+	attachment, err := client.UploadAttachment(context.Background(), AttachmentUploadRequest{
+		Parent:  "123",
+		Name:    "test.log",
+		Content: strings.NewReader("PASS\n"),
+	})
+	if err != nil {
+		t.Fatalf("UploadAttachment() error = %v", err)
+	}
+	if attachment.GID != "a1" || attachment.Name != "test.log" {
+		t.Errorf("attachment = %+v", attachment)
+	}
+}
+
+func TestAttachURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm() error = %v", err)
+		}
+		if got := r.FormValue("resource_subtype"); got != "external" {
+			t.Errorf("resource_subtype = %q, want external", got)
+		}
+		if got := r.FormValue("url"); got != "https://example.com/pr/1" {
+			t.Errorf("url = %q", got)
+		}
+		if got := r.FormValue("name"); got != "PR" {
+			t.Errorf("name = %q, want PR", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"gid":"a2","name":"PR","resource_subtype":"external"}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL))
+
+	if _, err := client.AttachURL(context.Background(), AttachmentURLRequest{Parent: "123", URL: "https://example.com/pr/1", Name: "PR"}); err != nil {
+		t.Fatalf("AttachURL() error = %v", err)
+	}
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Authorization = %q, want none for pre-signed URL", auth)
+		}
+		_, _ = w.Write([]byte("file contents"))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg)
+
+	var buf bytes.Buffer
+	n, err := client.DownloadAttachment(context.Background(), server.URL+"/signed", &buf)
+	if err != nil {
+		t.Fatalf("DownloadAttachment() error = %v", err)
+	}
+	if n != int64(len("file contents")) || buf.String() != "file contents" {
+		t.Errorf("downloaded %d bytes: %q", n, buf.String())
+	}
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/models"
+	"github.com/whoaa512/asana-cli/internal/parallel"
+)
+
+// MaxBatchActions is the most actions Asana accepts in a single /batch call.
+const MaxBatchActions = 10
+
+// MaxConcurrentRequests bounds how many requests are in flight at once when
+// reads fan out, well under Asana's limit of 50 concurrent reads per token.
+const MaxConcurrentRequests = 8
+
+// BatchClient submits several API actions through Asana's /batch endpoint.
+type BatchClient interface {
+	Batch(ctx context.Context, actions []BatchAction) ([]BatchResult, error)
+}
+
+type BatchAction struct {
+	RelativePath string         `json:"relative_path"`
+	Method       string         `json:"method"`
+	Data         any            `json:"data,omitempty"`
+	Options      map[string]any `json:"options,omitempty"`
+}
+
+type BatchResult struct {
+	Action     BatchAction     `json:"-"`
+	StatusCode int             `json:"status_code"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	Err        error           `json:"-"`
+}
+
+// Decode unmarshals the "data" envelope of a successful action's body.
+func (r BatchResult) Decode(v any) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	envelope := struct {
+		Data any `json:"data"`
+	}{Data: v}
+	if err := json.Unmarshal(r.Body, &envelope); err != nil {
+		return errors.NewGeneralError("failed to parse batch response", err)
+	}
+	return nil
+}
+
+// DecodePage is Decode for list actions. It also returns the body's
+// next_page, which is nil on the last page.
+func (r BatchResult) DecodePage(v any) (*models.PageInfo, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	envelope := struct {
+		Data     any              `json:"data"`
+		NextPage *models.PageInfo `json:"next_page"`
+	}{Data: v}
+	if err := json.Unmarshal(r.Body, &envelope); err != nil {
+		return nil, errors.NewGeneralError("failed to parse batch response", err)
+	}
+	return envelope.NextPage, nil
+}
+
+// Batch runs actions in chunks of MaxBatchActions. Results are returned in
+// the same order as actions; per-action failures are reported on the result
+// rather than aborting the remaining chunks. When every action is a read,
+// chunks are sent concurrently; otherwise they run in order.
+func (c *HTTPClient) Batch(ctx context.Context, actions []BatchAction) ([]BatchResult, error) {
+	results := make([]BatchResult, len(actions))
+	chunks := (len(actions) + MaxBatchActions - 1) / MaxBatchActions
+
+	workers := 1
+	if readOnly(actions) {
+		workers = MaxConcurrentRequests
+	}
+
+	err := parallel.Each(ctx, chunks, workers, func(ctx context.Context, i int) error {
+		start := i * MaxBatchActions
+		end := min(start+MaxBatchActions, len(actions))
+		chunk := actions[start:end]
+
+		chunkResults, err := c.batchChunk(ctx, chunk)
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			for j, action := range chunk {
+				results[start+j] = BatchResult{Action: action, Err: err}
+			}
+			return nil
+		}
+		copy(results[start:end], chunkResults)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// batchIdempotent reports whether every action in a batch is safe to
+// repeat, so the whole batch can be retried after a server error.
+func batchIdempotent(actions []BatchAction) bool {
+	for _, a := range actions {
+		if !idempotent(context.Background(), strings.ToUpper(a.Method), a.RelativePath) {
+			return false
+		}
+	}
+	return true
+}
+
+func readOnly(actions []BatchAction) bool {
+	for _, a := range actions {
+		if a.Method != "get" {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *HTTPClient) batchChunk(ctx context.Context, actions []BatchAction) ([]BatchResult, error) {
+	payload := struct {
+		Data struct {
+			Actions []BatchAction `json:"actions"`
+		} `json:"data"`
+	}{}
+	payload.Data.Actions = actions
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	var response struct {
+		Data []BatchResult `json:"data"`
+	}
+
+	ctx = withBudget(ctx, !readOnly(actions), len(actions))
+	ctx = withIdempotent(ctx, batchIdempotent(actions))
+	if err := c.post(ctx, "/batch", bytes.NewReader(body), &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) != len(actions) {
+		return nil, errors.NewGeneralError(fmt.Sprintf("batch returned %d results for %d actions", len(response.Data), len(actions)), nil)
+	}
+
+	for i := range response.Data {
+		response.Data[i].Action = actions[i]
+		response.Data[i].Err = c.checkError(response.Data[i].StatusCode, response.Data[i].Body)
+	}
+
+	return response.Data, nil
+}
+
+// GetTaskAction reads a task with the given opt_fields.
+func GetTaskAction(gid string, fields []string) BatchAction {
+	return BatchAction{
+		RelativePath: "/tasks/" + gid,
+		Method:       "get",
+		Options:      map[string]any{"fields": fields},
+	}
+}
+
+// ListDependentsAction reads the tasks that depend on a task.
+func ListDependentsAction(taskGID string, fields []string) BatchAction {
+	return BatchAction{
+		RelativePath: fmt.Sprintf("/tasks/%s/dependents", taskGID),
+		Method:       "get",
+		Options:      map[string]any{"fields": fields},
+	}
+}
+
+// ListSubtasksAction reads a page of up to limit subtasks of a task with the
+// given opt_fields, starting at offset (empty for the first page).
+func ListSubtasksAction(taskGID string, fields []string, limit int, offset string) BatchAction {
+	options := map[string]any{"fields": fields, "limit": limit}
+	if offset != "" {
+		options["offset"] = offset
+	}
+	return BatchAction{
+		RelativePath: fmt.Sprintf("/tasks/%s/subtasks", taskGID),
+		Method:       "get",
+		Options:      options,
+	}
+}
+
+func UpdateTaskAction(gid string, req models.TaskUpdateRequest) BatchAction {
+	return BatchAction{RelativePath: "/tasks/" + gid, Method: "put", Data: req}
+}
+
+func DeleteTaskAction(gid string) BatchAction {
+	return BatchAction{RelativePath: "/tasks/" + gid, Method: "delete"}
+}
+
+func AddTagAction(taskGID, tagGID string) BatchAction {
+	return BatchAction{
+		RelativePath: fmt.Sprintf("/tasks/%s/addTag", taskGID),
+		Method:       "post",
+		Data:         map[string]string{"tag": tagGID},
+	}
+}
+
+func RemoveTagAction(taskGID, tagGID string) BatchAction {
+	return BatchAction{
+		RelativePath: fmt.Sprintf("/tasks/%s/removeTag", taskGID),
+		Method:       "post",
+		Data:         map[string]string{"tag": tagGID},
+	}
+}
+
+func AddFollowersAction(taskGID string, followers []string) BatchAction {
+	return BatchAction{
+		RelativePath: fmt.Sprintf("/tasks/%s/addFollowers", taskGID),
+		Method:       "post",
+		Data:         map[string][]string{"followers": followers},
+	}
+}
+
+func AddToProjectAction(taskGID, projectGID string) BatchAction {
+	return BatchAction{
+		RelativePath: fmt.Sprintf("/tasks/%s/addProject", taskGID),
+		Method:       "post",
+		Data:         map[string]string{"project": projectGID},
+	}
+}
+
+func RemoveFromProjectAction(taskGID, projectGID string) BatchAction {
+	return BatchAction{
+		RelativePath: fmt.Sprintf("/tasks/%s/removeProject", taskGID),
+		Method:       "post",
+		Data:         map[string]string{"project": projectGID},
+	}
+}
+
+func AddTaskToSectionAction(sectionGID, taskGID string) BatchAction {
+	return BatchAction{
+		RelativePath: fmt.Sprintf("/sections/%s/addTask", sectionGID),
+		Method:       "post",
+		Data:         map[string]string{"task": taskGID},
+	}
+}
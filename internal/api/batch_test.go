@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+func TestBatchChunksActions(t *testing.T) {
+	var chunkSizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/batch" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+
+		var req struct {
+			Data struct {
+				Actions []BatchAction `json:"actions"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		chunkSizes = append(chunkSizes, len(req.Data.Actions))
+
+		var results []map[string]any
+		for _, action := range req.Data.Actions {
+			if action.RelativePath == "/tasks/missing" {
+				results = append(results, map[string]any{
+					"status_code": 404,
+					"body":        map[string]any{"errors": []map[string]any{{"message": "task not found"}}},
+				})
+				continue
+			}
+			results = append(results, map[string]any{
+				"status_code": 200,
+				"body":        map[string]any{"data": map[string]any{"gid": action.RelativePath[len("/tasks/"):]}},
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]any{"data": results}); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL))
+
+	completed := true
+	var actions []BatchAction
+	for i := 0; i < 12; i++ {
+		gid := fmt.Sprintf("%d", i)
+		if i == 11 {
+			gid = "missing"
+		}
+		actions = append(actions, UpdateTaskAction(gid, models.TaskUpdateRequest{Completed: &completed}))
+	}
+
+	results, err := client.Batch(context.Background(), actions)
+	if err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+
+	if len(chunkSizes) != 2 || chunkSizes[0] != MaxBatchActions || chunkSizes[1] != 2 {
+		t.Errorf("chunk sizes = %v, want [10 2]", chunkSizes)
+	}
+	if len(results) != 12 {
+		t.Fatalf("len(results) = %d, want 12", len(results))
+	}
+
+	var task models.Task
+	if err := results[3].Decode(&task); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if task.GID != "3" {
+		t.Errorf("task.GID = %q, want %q", task.GID, "3")
+	}
+
+	if results[11].Err == nil {
+		t.Fatal("expected error for missing task")
+	}
+	if code := errors.GetExitCode(results[11].Err); code != errors.ExitNotFound {
+		t.Errorf("exit code = %d, want %d", code, errors.ExitNotFound)
+	}
+	if results[11].Action.RelativePath != "/tasks/missing" {
+		t.Errorf("result action = %q, want /tasks/missing", results[11].Action.RelativePath)
+	}
+}
+
+func TestBatchActionPayloads(t *testing.T) {
+	action := AddTagAction("123", "456")
+	data, err := json.Marshal(action)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"relative_path":"/tasks/123/addTag","method":"post","data":{"tag":"456"}}`
+	if string(data) != want {
+		t.Errorf("AddTagAction JSON = %s, want %s", data, want)
+	}
+}
+
+func TestBatchConcurrentReadsKeepOrder(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		inFlight int
+		peak     int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		var req struct {
+			Data struct {
+				Actions []BatchAction `json:"actions"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		var results []map[string]any
+		for _, action := range req.Data.Actions {
+			results = append(results, map[string]any{
+				"status_code": 200,
+				"body":        map[string]any{"data": map[string]any{"gid": action.RelativePath[len("/tasks/"):]}},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": results})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL))
+
+	var actions []BatchAction
+	for i := 0; i < 45; i++ {
+		actions = append(actions, GetTaskAction(fmt.Sprintf("%d", i), []string{"name"}))
+	}
+
+	results, err := client.Batch(context.Background(), actions)
+	if err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	for i, r := range results {
+		var task models.Task
+		if err := r.Decode(&task); err != nil {
+			t.Fatal(err)
+		}
+		if task.GID != fmt.Sprintf("%d", i) {
+			t.Fatalf("results[%d].GID = %s, want results in action order", i, task.GID)
+		}
+	}
+	if peak < 2 {
+		t.Errorf("peak in-flight batches = %d, want read chunks sent concurrently", peak)
+	}
+}
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/cache"
+)
+
+// cacheTTLs is keyed by the last non-GID segment of a request path, so
+// /projects/1 and /workspaces/1/projects share a TTL. Zero disables caching.
+var cacheTTLs = map[string]time.Duration{
+	"me":                    time.Hour,
+	"workspaces":            time.Hour,
+	"users":                 time.Hour,
+	"projects":              10 * time.Minute,
+	"sections":              10 * time.Minute,
+	"tags":                  10 * time.Minute,
+	"teams":                 10 * time.Minute,
+	"custom_fields":         10 * time.Minute,
+	"custom_field_settings": 10 * time.Minute,
+	"attachments":           0,
+	"events":                0,
+	"webhooks":              0,
+}
+
+const defaultCacheTTL = time.Minute
+
+// cacheIgnoredKeys are fields whose GIDs are shared by nearly every response
+// (people, workspace). Tagging on them would make any mutation flush most of
+// the cache.
+var cacheIgnoredKeys = map[string]bool{
+	"workspace":    true,
+	"assignee":     true,
+	"followers":    true,
+	"created_by":   true,
+	"completed_by": true,
+}
+
+func WithCache(c *cache.Cache) Option {
+	return func(hc *HTTPClient) {
+		hc.cache = c
+		hc.cacheReads = true
+	}
+}
+
+// WithCacheRefresh skips cache lookups but still stores responses and
+// invalidates on mutations, so bypassing the cache leaves it consistent.
+func WithCacheRefresh() Option {
+	return func(hc *HTTPClient) {
+		hc.cacheReads = false
+	}
+}
+
+type skipCacheKey struct{}
+
+// SkipCacheReads returns a context under which requests behave as with
+// WithCacheRefresh, for callers that need one fresh read from a shared client.
+func SkipCacheReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipCacheKey{}, true)
+}
+
+func cacheReadsSkipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipCacheKey{}).(bool)
+	return skip
+}
+
+func (c *HTTPClient) cacheKey(path string) string {
+	sum := sha256.Sum256([]byte(c.token))
+	return hex.EncodeToString(sum[:8]) + " " + path
+}
+
+func cacheTTL(path string) time.Duration {
+	u, err := url.Parse(path)
+	if err != nil {
+		return 0
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if isGID(segments[i]) {
+			continue
+		}
+		if ttl, ok := cacheTTLs[segments[i]]; ok {
+			return ttl
+		}
+		return defaultCacheTTL
+	}
+	return 0
+}
+
+// responseTags tags a cached GET with the GIDs in its path and query and the
+// GIDs of the top-level items it returned.
+func responseTags(path string, body []byte) []string {
+	tags := pathTags(path)
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(body, &envelope) != nil {
+		return tags
+	}
+
+	var items []struct {
+		GID string `json:"gid"`
+	}
+	if json.Unmarshal(envelope.Data, &items) != nil {
+		var item struct {
+			GID string `json:"gid"`
+		}
+		if json.Unmarshal(envelope.Data, &item) == nil {
+			items = append(items, item)
+		}
+	}
+	for _, item := range items {
+		if item.GID != "" {
+			tags = append(tags, item.GID)
+		}
+	}
+	return dedupe(tags)
+}
+
+// mutationTags collects every GID a mutation touched: its path, the request
+// JSON (including batch relative_paths) and any GID in the response.
+func mutationTags(path string, reqBody []byte, contentType string, respBody []byte) []string {
+	tags := pathTags(path)
+
+	if contentType == "application/json" {
+		var v any
+		if json.Unmarshal(reqBody, &v) == nil {
+			collectGIDs(v, false, &tags)
+		}
+	}
+
+	var v any
+	if json.Unmarshal(respBody, &v) == nil {
+		collectGIDs(v, true, &tags)
+	}
+	return dedupe(tags)
+}
+
+// createdInWorkspace returns the workspace a create request names, which
+// cacheIgnoredKeys leaves out of its tags. Its lists (/workspaces/<gid>/...)
+// are tagged with that GID and gain the new resource.
+func createdInWorkspace(reqBody []byte) []string {
+	var req struct {
+		Data struct {
+			Workspace string `json:"workspace"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(reqBody, &req) != nil || !isGID(req.Data.Workspace) {
+		return nil
+	}
+	return []string{req.Data.Workspace}
+}
+
+// readOnlyBatch reports whether a request is a batch of GETs only, which
+// changes nothing and so invalidates nothing.
+func readOnlyBatch(path string, reqBody []byte) bool {
+	if path != "/batch" {
+		return false
+	}
+	var req struct {
+		Data struct {
+			Actions []BatchAction `json:"actions"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(reqBody, &req) != nil || len(req.Data.Actions) == 0 {
+		return false
+	}
+	return readOnly(req.Data.Actions)
+}
+
+func pathTags(path string) []string {
+	var tags []string
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil
+	}
+	for _, seg := range strings.Split(u.Path, "/") {
+		if isGID(seg) {
+			tags = append(tags, seg)
+		}
+	}
+	for key, values := range u.Query() {
+		if cacheIgnoredKeys[key] || strings.HasPrefix(key, "assignee") {
+			continue
+		}
+		for _, v := range values {
+			if isGID(v) {
+				tags = append(tags, v)
+			}
+		}
+	}
+	return tags
+}
+
+// collectGIDs walks decoded JSON. Request bodies reference resources by bare
+// GID strings; responses do so through "gid" fields.
+func collectGIDs(v any, gidFieldsOnly bool, tags *[]string) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if cacheIgnoredKeys[key] {
+				continue
+			}
+			if s, ok := child.(string); ok && gidFieldsOnly {
+				if key == "gid" && isGID(s) {
+					*tags = append(*tags, s)
+				}
+				continue
+			}
+			collectGIDs(child, gidFieldsOnly, tags)
+		}
+	case []any:
+		for _, child := range v {
+			collectGIDs(child, gidFieldsOnly, tags)
+		}
+	case string:
+		if gidFieldsOnly {
+			return
+		}
+		if isGID(v) {
+			*tags = append(*tags, v)
+		} else if strings.HasPrefix(v, "/") {
+			*tags = append(*tags, pathTags(v)...)
+		}
+	}
+}
+
+func isGID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func dedupe(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	out := tags[:0]
+	for _, t := range tags {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
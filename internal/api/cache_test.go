@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/cache"
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+func TestCacheServesRepeatedGetsAndInvalidatesOnMutation(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/p1/tasks":
+			gets++
+			_, _ = w.Write([]byte(`{"data":[{"gid":"101","name":"Task"}]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/tasks/101":
+			_, _ = w.Write([]byte(`{"data":{"gid":"101","name":"Task","completed":true}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL), WithCache(cache.New(t.TempDir())))
+	ctx := context.Background()
+	opts := TaskListOptions{Project: "p1"}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.ListTasks(ctx, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if gets != 1 {
+		t.Errorf("server saw %d GETs, want 1 (second served from cache)", gets)
+	}
+
+	completed := true
+	if _, err := client.UpdateTask(ctx, "101", models.TaskUpdateRequest{Completed: &completed}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ListTasks(ctx, opts); err != nil {
+		t.Fatal(err)
+	}
+	if gets != 2 {
+		t.Errorf("server saw %d GETs, want 2 (list invalidated by update)", gets)
+	}
+}
+
+func TestCacheRefreshSkipsReads(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		gets++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"gid":"1","name":"Me"}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	c := cache.New(t.TempDir())
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL), WithCache(c), WithCacheRefresh())
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetMe(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if gets != 2 {
+		t.Errorf("server saw %d GETs, want 2", gets)
+	}
+	if stats, _ := c.Stats(); stats.Entries != 1 {
+		t.Errorf("cache entries = %d, want 1 (refresh still stores)", stats.Entries)
+	}
+}
+
+func TestSkipCacheReads(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		gets++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"gid":"1","name":"Me"}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL), WithCache(cache.New(t.TempDir())))
+	ctx := context.Background()
+
+	for _, c := range []context.Context{ctx, SkipCacheReads(ctx), ctx} {
+		if _, err := client.GetMe(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if gets != 2 {
+		t.Errorf("server saw %d GETs, want 2 (only the skipped read bypasses the cache)", gets)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	tests := []struct {
+		path string
+		want time.Duration
+	}{
+		{"/users/me", time.Hour},
+		{"/projects/1/tasks?limit=10", time.Minute},
+		{"/workspaces/1/projects", 10 * time.Minute},
+		{"/attachments/5", 0},
+		{"/tasks/1/stories", time.Minute},
+	}
+	for _, tt := range tests {
+		if got := cacheTTL(tt.path); got != tt.want {
+			t.Errorf("cacheTTL(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCacheInvalidatesWorkspaceListsOnCreate(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/workspaces/9/projects":
+			gets++
+			_, _ = w.Write([]byte(`{"data":[{"gid":"1","name":"Old"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/projects":
+			_, _ = w.Write([]byte(`{"data":{"gid":"2","name":"New","workspace":{"gid":"9"}}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL), WithCache(cache.New(t.TempDir())))
+	ctx := context.Background()
+	opts := ProjectListOptions{Workspace: "9"}
+
+	if _, err := client.ListProjects(ctx, opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateProject(ctx, models.ProjectCreateRequest{Name: "New", Workspace: "9"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ListProjects(ctx, opts); err != nil {
+		t.Fatal(err)
+	}
+	if gets != 2 {
+		t.Errorf("server saw %d GETs, want 2 (list invalidated by create)", gets)
+	}
+}
+
+func TestCacheKeptByReadOnlyBatch(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tasks/101":
+			gets++
+			_, _ = w.Write([]byte(`{"data":{"gid":"101","name":"Task"}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/batch":
+			_, _ = w.Write([]byte(`{"data":[{"status_code":200,"body":{"data":[]}}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL), WithCache(cache.New(t.TempDir())))
+	ctx := context.Background()
+
+	if _, err := client.GetTask(ctx, "101"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Batch(ctx, []BatchAction{ListDependentsAction("101", nil)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetTask(ctx, "101"); err != nil {
+		t.Fatal(err)
+	}
+	if gets != 1 {
+		t.Errorf("server saw %d GETs, want 1 (a read-only batch keeps the cache)", gets)
+	}
+}
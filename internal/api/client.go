@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"io"
 
 	"github.com/whoaa512/asana-cli/internal/models"
 )
@@ -9,7 +10,11 @@ import (
 const BaseURL = "https://app.asana.com/api/1.0"
 
 type Client interface {
+	BatchClient
+
 	GetMe(ctx context.Context) (*models.User, error)
+	ListUsers(ctx context.Context, opts UserListOptions) (*models.ListResponse[models.User], error)
+	GetUser(ctx context.Context, gid string) (*models.User, error)
 	ListWorkspaces(ctx context.Context, limit int) (*models.ListResponse[models.Workspace], error)
 	GetWorkspace(ctx context.Context, gid string) (*models.Workspace, error)
 
@@ -64,4 +69,21 @@ type Client interface {
 	GetTeam(ctx context.Context, gid string) (*models.Team, error)
 
 	SearchTasks(ctx context.Context, opts SearchTasksOptions) (*models.ListResponse[models.Task], error)
+
+	ListCustomFieldSettings(ctx context.Context, opts CustomFieldSettingListOptions) (*models.ListResponse[models.CustomFieldSetting], error)
+	ListCustomFields(ctx context.Context, opts CustomFieldListOptions) (*models.ListResponse[models.CustomField], error)
+	GetCustomField(ctx context.Context, gid string) (*models.CustomField, error)
+
+	ListAttachments(ctx context.Context, opts AttachmentListOptions) (*models.ListResponse[models.Attachment], error)
+	GetAttachment(ctx context.Context, gid string) (*models.Attachment, error)
+	UploadAttachment(ctx context.Context, req AttachmentUploadRequest) (*models.Attachment, error)
+	AttachURL(ctx context.Context, req AttachmentURLRequest) (*models.Attachment, error)
+	DeleteAttachment(ctx context.Context, gid string) error
+	DownloadAttachment(ctx context.Context, downloadURL string, w io.Writer) (int64, error)
+
+	GetEvents(ctx context.Context, resource, sync string) (*EventsPage, error)
+
+	ListWebhooks(ctx context.Context, opts WebhookListOptions) (*models.ListResponse[models.Webhook], error)
+	CreateWebhook(ctx context.Context, req models.WebhookCreateRequest) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, gid string) error
 }
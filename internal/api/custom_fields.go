@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+// CustomFieldOptFields requests the definition of a custom field, including
+// enum options, so names can be resolved to GIDs.
+var CustomFieldOptFields = []string{
+	"name",
+	"resource_subtype",
+	"description",
+	"precision",
+	"enum_options.name",
+	"enum_options.color",
+	"enum_options.enabled",
+}
+
+// TaskCustomFieldOptFields requests the values of a task's custom fields.
+var TaskCustomFieldOptFields = []string{
+	"custom_fields.name",
+	"custom_fields.resource_subtype",
+	"custom_fields.display_value",
+	"custom_fields.text_value",
+	"custom_fields.number_value",
+	"custom_fields.enum_value.name",
+	"custom_fields.multi_enum_values.name",
+	"custom_fields.date_value",
+	"custom_fields.people_value.name",
+	"custom_fields.enum_options.name",
+	"custom_fields.enum_options.enabled",
+}
+
+type CustomFieldSettingListOptions struct {
+	Project string
+	Limit   int
+	Offset  string
+}
+
+func (c *HTTPClient) ListCustomFieldSettings(ctx context.Context, opts CustomFieldSettingListOptions) (*models.ListResponse[models.CustomFieldSetting], error) {
+	if opts.Project == "" {
+		return nil, fmt.Errorf("project is required")
+	}
+
+	path := fmt.Sprintf("/projects/%s/custom_field_settings", opts.Project)
+
+	fields := []string{"is_important"}
+	for _, f := range CustomFieldOptFields {
+		fields = append(fields, "custom_field."+f)
+	}
+
+	params := url.Values{}
+	params.Set("opt_fields", strings.Join(fields, ","))
+	if opts.Limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+	}
+	if opts.Offset != "" {
+		params.Set("offset", opts.Offset)
+	}
+	path += "?" + params.Encode()
+
+	var response struct {
+		Data     []models.CustomFieldSetting `json:"data"`
+		NextPage *models.PageInfo            `json:"next_page,omitempty"`
+	}
+
+	if err := c.get(ctx, path, &response); err != nil {
+		return nil, err
+	}
+
+	return &models.ListResponse[models.CustomFieldSetting]{
+		Data:     response.Data,
+		NextPage: response.NextPage,
+	}, nil
+}
+
+type CustomFieldListOptions struct {
+	Workspace string
+	Limit     int
+	Offset    string
+}
+
+func (c *HTTPClient) ListCustomFields(ctx context.Context, opts CustomFieldListOptions) (*models.ListResponse[models.CustomField], error) {
+	if opts.Workspace == "" {
+		return nil, fmt.Errorf("workspace is required")
+	}
+
+	path := fmt.Sprintf("/workspaces/%s/custom_fields", opts.Workspace)
+
+	params := url.Values{}
+	params.Set("opt_fields", strings.Join(CustomFieldOptFields, ","))
+	if opts.Limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+	}
+	if opts.Offset != "" {
+		params.Set("offset", opts.Offset)
+	}
+	path += "?" + params.Encode()
+
+	var response struct {
+		Data     []models.CustomField `json:"data"`
+		NextPage *models.PageInfo     `json:"next_page,omitempty"`
+	}
+
+	if err := c.get(ctx, path, &response); err != nil {
+		return nil, err
+	}
+
+	return &models.ListResponse[models.CustomField]{
+		Data:     response.Data,
+		NextPage: response.NextPage,
+	}, nil
+}
+
+func (c *HTTPClient) GetCustomField(ctx context.Context, gid string) (*models.CustomField, error) {
+	path := "/custom_fields/" + gid + "?opt_fields=" + url.QueryEscape(strings.Join(CustomFieldOptFields, ","))
+
+	var response struct {
+		Data models.CustomField `json:"data"`
+	}
+
+	if err := c.get(ctx, path, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/config"
+)
+
+func TestListCustomFieldSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/p1/custom_field_settings" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if optFields := r.URL.Query().Get("opt_fields"); !strings.Contains(optFields, "custom_field.enum_options.name") {
+			t.Errorf("opt_fields = %q, want enum options", optFields)
+		}
+
+		resp := map[string]any{
+			"data": []map[string]any{{
+				"gid":          "s1",
+				"is_important": true,
+				"custom_field": map[string]any{
+					"gid":              "cf1",
+					"name":             "Priority",
+					"resource_subtype": "enum",
+					"enum_options": []map[string]any{
+						{"gid": "o1", "name": "High"},
+						{"gid": "o2", "name": "Low"},
+					},
+				},
+			}},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL))
+
+	result, err := client.ListCustomFieldSettings(context.Background(), CustomFieldSettingListOptions{Project: "p1"})
+	if err != nil {
+		t.Fatalf("ListCustomFieldSettings() error = %v", err)
+	}
+
+	if len(result.Data) != 1 {
+		t.Fatalf("len(Data) = %d, want 1", len(result.Data))
+	}
+	field := result.Data[0].CustomField
+	if field.Name != "Priority" || field.ResourceSubtype != "enum" || len(field.EnumOptions) != 2 {
+		t.Errorf("custom field = %+v", field)
+	}
+}
+
+func TestSearchTasksCustomFieldParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("custom_fields.cf1.value"); got != "o1" {
+			t.Errorf("custom_fields.cf1.value = %q, want o1", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL))
+
+	_, err := client.SearchTasks(context.Background(), SearchTasksOptions{
+		Workspace:    "w1",
+		Text:         "bug",
+		CustomFields: map[string]string{"cf1.value": "o1"},
+	})
+	if err != nil {
+		t.Fatalf("SearchTasks() error = %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+// SyncTokenError is returned by GetEvents when Asana answers 412: no sync
+// token was given, or it expired. Sync is a fresh token to continue from.
+type SyncTokenError struct {
+	Sync    string
+	Message string
+}
+
+func (e *SyncTokenError) Error() string {
+	return e.Message
+}
+
+type EventsPage struct {
+	Data    []models.Event `json:"data"`
+	Sync    string         `json:"sync"`
+	HasMore bool           `json:"has_more"`
+}
+
+func (c *HTTPClient) GetEvents(ctx context.Context, resource, sync string) (*EventsPage, error) {
+	if resource == "" {
+		return nil, fmt.Errorf("resource is required")
+	}
+
+	params := url.Values{}
+	params.Set("resource", resource)
+	if sync != "" {
+		params.Set("sync", sync)
+	}
+
+	var page EventsPage
+	if err := c.get(ctx, "/events?"+params.Encode(), &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+func parseSyncTokenError(body []byte, msg string) error {
+	var resp struct {
+		Sync string `json:"sync"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Sync == "" {
+		return nil
+	}
+	return &SyncTokenError{Sync: resp.Sync, Message: msg}
+}
+
+type EventBatch struct {
+	Events []models.Event
+	// Sync is the token to persist once Events have been handled.
+	Sync string
+	// Expired is set when the previous token had expired and events since
+	// then were lost; callers should refresh any state they derive from them.
+	Expired bool
+}
+
+type WatchOptions struct {
+	Resource string
+	Sync     string
+	Interval time.Duration
+	// Once stops after the first poll that has no more events queued.
+	Once bool
+}
+
+// WatchEvents polls /events for a resource and calls fn with each batch. A
+// missing token is exchanged for one that starts now. fn should persist
+// batch.Sync only after handling the events, so a resumed watch neither
+// skips nor replays them.
+func WatchEvents(ctx context.Context, c Client, opts WatchOptions, fn func(EventBatch) error) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	sync := opts.Sync
+	for {
+		page, err := c.GetEvents(ctx, opts.Resource, sync)
+
+		var syncErr *SyncTokenError
+		switch {
+		case stderrors.As(err, &syncErr):
+			if err := fn(EventBatch{Sync: syncErr.Sync, Expired: sync != ""}); err != nil {
+				return err
+			}
+			sync = syncErr.Sync
+			if opts.Once {
+				return nil
+			}
+			continue
+		case err != nil:
+			return err
+		}
+
+		if err := fn(EventBatch{Events: page.Data, Sync: page.Sync}); err != nil {
+			return err
+		}
+		sync = page.Sync
+
+		if page.HasMore {
+			continue
+		}
+		if opts.Once {
+			return nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/config"
+)
+
+func TestGetEventsSyncTokenFlow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events" || r.URL.Query().Get("resource") != "p1" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Query().Get("sync") {
+		case "":
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Sync token invalid or too old"}],"sync":"s1"}`))
+		case "s1":
+			_, _ = w.Write([]byte(`{"data":[{"action":"changed","resource":{"gid":"101","resource_type":"task"}}],"sync":"s2","has_more":true}`))
+		case "s2":
+			_, _ = w.Write([]byte(`{"data":[{"action":"added","resource":{"gid":"102","resource_type":"task"}}],"sync":"s3","has_more":false}`))
+		case "stale":
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Sync token invalid or too old"}],"sync":"s9"}`))
+		default:
+			t.Errorf("unexpected sync token %q", r.URL.Query().Get("sync"))
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	var batches []EventBatch
+	collect := func(b EventBatch) error {
+		batches = append(batches, b)
+		return nil
+	}
+
+	if err := WatchEvents(ctx, client, WatchOptions{Resource: "p1", Once: true}, collect); err != nil {
+		t.Fatalf("WatchEvents() error = %v", err)
+	}
+	if len(batches) != 1 || batches[0].Sync != "s1" || batches[0].Expired || len(batches[0].Events) != 0 {
+		t.Errorf("initial batches = %+v, want one empty batch with sync s1", batches)
+	}
+
+	batches = nil
+	if err := WatchEvents(ctx, client, WatchOptions{Resource: "p1", Sync: "s1", Once: true}, collect); err != nil {
+		t.Fatalf("WatchEvents() error = %v", err)
+	}
+	if len(batches) != 2 {
+		t.Fatalf("got %d batches, want 2 (has_more followed)", len(batches))
+	}
+	if batches[0].Events[0].Resource.GID != "101" || batches[1].Sync != "s3" {
+		t.Errorf("batches = %+v", batches)
+	}
+
+	batches = nil
+	if err := WatchEvents(ctx, client, WatchOptions{Resource: "p1", Sync: "stale", Once: true}, collect); err != nil {
+		t.Fatalf("WatchEvents() error = %v", err)
+	}
+	if len(batches) != 1 || !batches[0].Expired || batches[0].Sync != "s9" {
+		t.Errorf("expired batches = %+v, want Expired with sync s9", batches)
+	}
+}
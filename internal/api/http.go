@@ -7,21 +7,22 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/whoaa512/asana-cli/internal/cache"
 	"github.com/whoaa512/asana-cli/internal/config"
 	"github.com/whoaa512/asana-cli/internal/errors"
 	"github.com/whoaa512/asana-cli/internal/models"
 )
 
-const (
-	maxRetries     = 3
-	initialBackoff = 1 * time.Second
-	maxBackoff     = 30 * time.Second
-)
-
 type HTTPClient struct {
 	baseURL    string
 	token      string
@@ -29,6 +30,16 @@ type HTTPClient struct {
 	debug      bool
 	debugOut   io.Writer
 	rng        *rand.Rand
+	cache      *cache.Cache
+	cacheReads bool
+
+	// mu guards rng and pausedUntil, which requests on other goroutines
+	// share.
+	mu          sync.Mutex
+	pausedUntil time.Time
+
+	reads, writes *budget
+	retry         RetryPolicy
 }
 
 type Option func(*HTTPClient)
@@ -55,6 +66,8 @@ func NewHTTPClient(cfg *config.Config, opts ...Option) *HTTPClient {
 		},
 		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
+	c.reads, c.writes = DefaultRateLimits.budgets()
+	c.retry = DefaultRetryPolicy
 
 	for _, opt := range opts {
 		opt(c)
@@ -101,10 +114,133 @@ func (c *HTTPClient) do(ctx context.Context, method, path string, body io.Reader
 		}
 	}
 
-	return c.doWithRetry(ctx, method, path, bodyBytes, result, 0)
+	contentType := ""
+	if bodyBytes != nil {
+		contentType = "application/json"
+	}
+
+	if method == http.MethodGet && c.cache != nil && c.cacheReads && !cacheReadsSkipped(ctx) {
+		if cached, ok := c.cache.Get(c.cacheKey(path)); ok {
+			if c.debug && c.debugOut != nil {
+				_, _ = fmt.Fprintf(c.debugOut, "[DEBUG] %s %s (cached)\n", method, c.baseURL+path)
+			}
+			if result == nil {
+				return nil
+			}
+			if err := json.Unmarshal(cached, result); err == nil {
+				return nil
+			}
+		}
+	}
+
+	return c.doWithRetry(ctx, method, path, bodyBytes, contentType, result)
 }
 
-func (c *HTTPClient) doWithRetry(ctx context.Context, method, path string, bodyBytes []byte, result any, attempt int) error {
+type multipartFile struct {
+	FieldName string
+	FileName  string
+	Content   io.Reader
+}
+
+// doMultipart POSTs a multipart/form-data body. The encoded body is buffered
+// like JSON bodies so rate-limited requests can be replayed.
+func (c *HTTPClient) doMultipart(ctx context.Context, path string, fields map[string]string, file *multipartFile, result any) error {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			return errors.NewGeneralError("failed to encode request", err)
+		}
+	}
+
+	if file != nil {
+		contentType := mime.TypeByExtension(filepath.Ext(file.FileName))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, file.FieldName, quoteEscaper.Replace(file.FileName)))
+		header.Set("Content-Type", contentType)
+
+		part, err := w.CreatePart(header)
+		if err != nil {
+			return errors.NewGeneralError("failed to encode request", err)
+		}
+		if _, err := io.Copy(part, file.Content); err != nil {
+			return errors.NewGeneralError("failed to read file", err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return errors.NewGeneralError("failed to encode request", err)
+	}
+
+	return c.doWithRetry(ctx, http.MethodPost, path, buf.Bytes(), w.FormDataContentType(), result)
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func (c *HTTPClient) doWithRetry(ctx context.Context, method, path string, bodyBytes []byte, contentType string, result any) error {
+	var attempts []Attempt
+	for attempt := 0; ; attempt++ {
+		status, header, respBody, err := c.send(ctx, method, path, bodyBytes, contentType)
+		if err == nil {
+			err = c.checkError(status, respBody)
+		}
+		if err == nil {
+			c.updateCache(method, path, bodyBytes, contentType, respBody)
+			if result != nil {
+				if err := json.Unmarshal(respBody, result); err != nil {
+					return errors.NewGeneralError("failed to parse response", err)
+				}
+			}
+			return nil
+		}
+
+		var retryAfter time.Duration
+		if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+			retryAfter = parseRetryAfter(header.Get("Retry-After"))
+		}
+		if status == http.StatusTooManyRequests {
+			err = errors.NewRateLimitedError(formatRetryAfter(retryAfter))
+		}
+
+		record := Attempt{Attempt: attempt + 1, Status: status, Error: errors.AsCLIError(err).Error()}
+		if !c.shouldRetry(ctx, method, path, status, err) || attempt >= c.retry.MaxRetries {
+			return withAttempts(err, append(attempts, record))
+		}
+
+		waitTime := c.calculateBackoff(attempt, retryAfter)
+		record.Wait = waitTime.String()
+		attempts = append(attempts, record)
+
+		if c.debug && c.debugOut != nil {
+			reason := "Rate limited"
+			if status != http.StatusTooManyRequests {
+				reason = "Request failed (" + record.Error + ")"
+			}
+			_, _ = fmt.Fprintf(c.debugOut, "[DEBUG] %s, retrying in %s (attempt %d/%d)\n", reason, waitTime, attempt+1, c.retry.MaxRetries)
+		}
+
+		if status == http.StatusTooManyRequests {
+			c.pause(waitTime)
+			continue
+		}
+		if err := sleep(ctx, waitTime); err != nil {
+			return err
+		}
+	}
+}
+
+// send makes one attempt at a request. A non-nil error means no response
+// was read; HTTP error statuses are returned for the caller to check.
+func (c *HTTPClient) send(ctx context.Context, method, path string, bodyBytes []byte, contentType string) (int, http.Header, []byte, error) {
+	if err := c.waitForPause(ctx); err != nil {
+		return 0, nil, nil, err
+	}
+
 	url := c.baseURL + path
 
 	var body io.Reader
@@ -114,27 +250,39 @@ func (c *HTTPClient) doWithRetry(ctx context.Context, method, path string, bodyB
 
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
-		return errors.NewGeneralError("failed to create request", err)
+		return 0, nil, nil, errors.NewGeneralError("failed to create request", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.token)
 	req.Header.Set("Accept", "application/json")
-	if bodyBytes != nil {
-		req.Header.Set("Content-Type", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	if c.debug && c.debugOut != nil {
 		_, _ = fmt.Fprintf(c.debugOut, "[DEBUG] %s %s\n", method, url)
 		_, _ = fmt.Fprintf(c.debugOut, "[DEBUG] Authorization: Bearer %s...\n", truncateToken(c.token))
-		if bodyBytes != nil {
+		if bodyBytes != nil && contentType == "application/json" {
 			_, _ = fmt.Fprintf(c.debugOut, "[DEBUG] Request Body: %s\n", truncateBody(string(bodyBytes)))
+		} else if bodyBytes != nil {
+			_, _ = fmt.Fprintf(c.debugOut, "[DEBUG] Request Body: <%s, %d bytes>\n", contentType, len(bodyBytes))
 		}
 	}
 
+	b, cost := c.budgetFor(ctx, method)
+	release, waited, err := b.acquire(ctx, cost)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if c.debug && c.debugOut != nil && waited >= time.Millisecond {
+		_, _ = fmt.Fprintf(c.debugOut, "[DEBUG] Rate limiter: waited %s for %s budget\n", waited.Round(time.Millisecond), b.name)
+	}
+
 	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return errors.NewNetworkError("request failed", err)
+		release()
+		return 0, nil, nil, errors.NewNetworkError("request failed", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -143,48 +291,43 @@ func (c *HTTPClient) doWithRetry(ctx context.Context, method, path string, bodyB
 	}
 
 	respBody, err := io.ReadAll(resp.Body)
+	release()
 	if err != nil {
-		return errors.NewNetworkError("failed to read response", err)
+		return resp.StatusCode, resp.Header, nil, errors.NewNetworkError("failed to read response", err)
 	}
 
 	if c.debug && c.debugOut != nil {
 		_, _ = fmt.Fprintf(c.debugOut, "[DEBUG] Body: %s\n", truncateBody(string(respBody)))
 	}
 
-	if resp.StatusCode == http.StatusTooManyRequests {
-		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
-		if attempt >= maxRetries {
-			return errors.NewRateLimitedError(formatRetryAfter(retryAfter))
-		}
-
-		waitTime := c.calculateBackoff(attempt, retryAfter)
-		if c.debug && c.debugOut != nil {
-			_, _ = fmt.Fprintf(c.debugOut, "[DEBUG] Rate limited, retrying in %s (attempt %d/%d)\n", waitTime, attempt+1, maxRetries)
-		}
-
-		timer := time.NewTimer(waitTime)
-		defer timer.Stop()
-
-		select {
-		case <-ctx.Done():
-			return errors.NewNetworkError("request cancelled", ctx.Err())
-		case <-timer.C:
-		}
-
-		return c.doWithRetry(ctx, method, path, bodyBytes, result, attempt+1)
-	}
+	return resp.StatusCode, resp.Header, respBody, nil
+}
 
-	if err := c.checkError(resp.StatusCode, respBody); err != nil {
-		return err
+// updateCache stores successful GETs and invalidates entries touched by
+// mutations. Cache failures never fail the request.
+func (c *HTTPClient) updateCache(method, path string, reqBody []byte, contentType string, respBody []byte) {
+	if c.cache == nil {
+		return
 	}
 
-	if result != nil {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return errors.NewGeneralError("failed to parse response", err)
+	var err error
+	if method == http.MethodGet {
+		err = c.cache.Set(c.cacheKey(path), respBody, cacheTTL(path), responseTags(path, respBody))
+	} else if !readOnlyBatch(path, reqBody) {
+		tags := mutationTags(path, reqBody, contentType, respBody)
+		if method == http.MethodPost {
+			tags = append(tags, createdInWorkspace(reqBody)...)
+		}
+		var n int
+		n, err = c.cache.Invalidate(tags)
+		if c.debug && c.debugOut != nil && n > 0 {
+			_, _ = fmt.Fprintf(c.debugOut, "[DEBUG] Invalidated %d cached responses\n", n)
 		}
 	}
 
-	return nil
+	if err != nil && c.debug && c.debugOut != nil {
+		_, _ = fmt.Fprintf(c.debugOut, "[DEBUG] Cache error: %v\n", err)
+	}
 }
 
 func (c *HTTPClient) checkError(statusCode int, body []byte) error {
@@ -218,6 +361,11 @@ func (c *HTTPClient) checkError(statusCode int, body []byte) error {
 		return errors.NewNotFoundError("resource")
 	case http.StatusTooManyRequests:
 		return errors.NewRateLimitedError("")
+	case http.StatusPreconditionFailed:
+		if err := parseSyncTokenError(body, msg); err != nil {
+			return err
+		}
+		return errors.NewGeneralError(fmt.Sprintf("API error %d: %s", statusCode, msg), nil)
 	default:
 		return errors.NewGeneralError(fmt.Sprintf("API error %d: %s", statusCode, msg), nil)
 	}
@@ -258,12 +406,38 @@ func (c *HTTPClient) calculateBackoff(attempt int, retryAfter time.Duration) tim
 	if retryAfter > 0 {
 		return retryAfter
 	}
-	backoff := initialBackoff * (1 << attempt)
-	if backoff > maxBackoff {
-		backoff = maxBackoff
+	backoff := c.retry.InitialBackoff * (1 << attempt)
+	if backoff > c.retry.MaxBackoff || backoff <= 0 {
+		backoff = c.retry.MaxBackoff
+	}
+	var jitter time.Duration
+	if n := int64(backoff) / 4; n > 0 {
+		c.mu.Lock()
+		jitter = time.Duration(c.rng.Int63n(n))
+		c.mu.Unlock()
 	}
-	jitter := time.Duration(c.rng.Int63n(int64(backoff) / 4))
 	return backoff + jitter
 }
 
+// pause holds back every request on this client for d. A 429 means the
+// token as a whole is over its quota, so concurrent requests wait it out
+// together rather than each tripping the limit in turn.
+func (c *HTTPClient) pause(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if until := time.Now().Add(d); until.After(c.pausedUntil) {
+		c.pausedUntil = until
+	}
+}
+
+func (c *HTTPClient) waitForPause(ctx context.Context) error {
+	c.mu.Lock()
+	wait := time.Until(c.pausedUntil)
+	c.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	return sleep(ctx, wait)
+}
+
 var _ Client = (*HTTPClient)(nil)
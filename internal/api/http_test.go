@@ -123,7 +123,7 @@ func TestAPIErrors(t *testing.T) {
 			defer server.Close()
 
 			cfg := &config.Config{AccessToken: "test", Timeout: 5 * time.Second}
-			client := NewHTTPClient(cfg, WithBaseURL(server.URL))
+			client := NewHTTPClient(cfg, WithBaseURL(server.URL), WithRetryPolicy(fastRetry))
 
 			_, err := client.GetMe(context.Background())
 			if err == nil {
@@ -168,3 +168,29 @@ func TestDebugOutput(t *testing.T) {
 		t.Error("debug output should NOT contain full token")
 	}
 }
+
+func TestRateLimitPauseIsShared(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": {"gid": "1", "name": "Me"}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL))
+
+	client.pause(100 * time.Millisecond)
+	start := time.Now()
+	if _, err := client.GetMe(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("request sent after %s, want it held for the shared pause", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client.pause(time.Minute)
+	cancel()
+	if _, err := client.GetMe(ctx); errors.GetExitCode(err) != errors.ExitNetworkError {
+		t.Errorf("GetMe() during pause with cancelled context = %v, want network error", err)
+	}
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+// MaxPageSize is the largest page Asana will return for list endpoints.
+const MaxPageSize = 100
+
+// PageFunc fetches a single page of results starting at offset.
+type PageFunc[T any] func(ctx context.Context, offset string, limit int) (*models.ListResponse[T], error)
+
+type PageOptions struct {
+	Offset   string
+	PageSize int
+	MaxItems int
+}
+
+// EachPage follows next_page links, calling fn with every page until the
+// results are exhausted, MaxItems is reached, or fn returns false. Page sizes
+// shrink as MaxItems is approached so a page is never split, which keeps the
+// returned next_page offset valid for resuming.
+func EachPage[T any](ctx context.Context, opts PageOptions, fetch PageFunc[T], fn func(page []T) (bool, error)) (*models.PageInfo, error) {
+	pageSize := opts.PageSize
+	if pageSize <= 0 || pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	offset := opts.Offset
+	fetched := 0
+	for {
+		limit := pageSize
+		if opts.MaxItems > 0 {
+			remaining := opts.MaxItems - fetched
+			if remaining < limit {
+				limit = remaining
+			}
+		}
+
+		page, err := fetch(ctx, offset, limit)
+		if err != nil {
+			return nil, err
+		}
+		fetched += len(page.Data)
+
+		more, err := fn(page.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		if page.NextPage == nil || page.NextPage.Offset == "" {
+			return nil, nil
+		}
+		if !more || (opts.MaxItems > 0 && fetched >= opts.MaxItems) {
+			return page.NextPage, nil
+		}
+		offset = page.NextPage.Offset
+	}
+}
+
+// Paginate collects every page into a single ListResponse. NextPage is set
+// only when MaxItems stopped pagination before the results were exhausted.
+func Paginate[T any](ctx context.Context, opts PageOptions, fetch PageFunc[T]) (*models.ListResponse[T], error) {
+	data := []T{}
+	next, err := EachPage(ctx, opts, fetch, func(page []T) (bool, error) {
+		data = append(data, page...)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ListResponse[T]{
+		Data:     data,
+		NextPage: next,
+	}, nil
+}
+
+func TaskPages(c Client, opts TaskListOptions) PageFunc[models.Task] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Task], error) {
+		opts.Offset = offset
+		opts.Limit = limit
+		return c.ListTasks(ctx, opts)
+	}
+}
+
+func SearchPages(c Client, opts SearchTasksOptions) PageFunc[models.Task] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Task], error) {
+		opts.Offset = offset
+		opts.Limit = limit
+		return c.SearchTasks(ctx, opts)
+	}
+}
+
+func SubtaskPages(c Client, taskGID string) PageFunc[models.Task] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Task], error) {
+		return c.ListSubtasks(ctx, taskGID, limit, offset)
+	}
+}
+
+func StoryPages(c Client, taskGID string) PageFunc[models.Story] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Story], error) {
+		return c.ListStories(ctx, taskGID, limit, offset)
+	}
+}
+
+func ProjectPages(c Client, opts ProjectListOptions) PageFunc[models.Project] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Project], error) {
+		opts.Offset = offset
+		opts.Limit = limit
+		return c.ListProjects(ctx, opts)
+	}
+}
+
+func UserProjectPages(c Client, opts UserProjectListOptions) PageFunc[models.Project] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Project], error) {
+		opts.Offset = offset
+		opts.Limit = limit
+		return c.ListUserProjects(ctx, opts)
+	}
+}
+
+func SectionPages(c Client, opts SectionListOptions) PageFunc[models.Section] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Section], error) {
+		opts.Offset = offset
+		opts.Limit = limit
+		return c.ListSections(ctx, opts)
+	}
+}
+
+func TagPages(c Client, opts TagListOptions) PageFunc[models.Tag] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Tag], error) {
+		opts.Offset = offset
+		opts.Limit = limit
+		return c.ListTags(ctx, opts)
+	}
+}
+
+func UserPages(c Client, opts UserListOptions) PageFunc[models.User] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.User], error) {
+		opts.Offset = offset
+		opts.Limit = limit
+		return c.ListUsers(ctx, opts)
+	}
+}
+
+func TeamPages(c Client, opts TeamListOptions) PageFunc[models.Team] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Team], error) {
+		opts.Offset = offset
+		opts.Limit = limit
+		return c.ListTeams(ctx, opts)
+	}
+}
+
+func UserTeamPages(c Client, opts UserTeamListOptions) PageFunc[models.Team] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Team], error) {
+		opts.Offset = offset
+		opts.Limit = limit
+		return c.ListUserTeams(ctx, opts)
+	}
+}
+
+func CustomFieldSettingPages(c Client, opts CustomFieldSettingListOptions) PageFunc[models.CustomFieldSetting] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.CustomFieldSetting], error) {
+		opts.Offset = offset
+		opts.Limit = limit
+		return c.ListCustomFieldSettings(ctx, opts)
+	}
+}
+
+func CustomFieldPages(c Client, opts CustomFieldListOptions) PageFunc[models.CustomField] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.CustomField], error) {
+		opts.Offset = offset
+		opts.Limit = limit
+		return c.ListCustomFields(ctx, opts)
+	}
+}
+
+func AttachmentPages(c Client, opts AttachmentListOptions) PageFunc[models.Attachment] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Attachment], error) {
+		opts.Offset = offset
+		opts.Limit = limit
+		return c.ListAttachments(ctx, opts)
+	}
+}
+
+func WebhookPages(c Client, opts WebhookListOptions) PageFunc[models.Webhook] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Webhook], error) {
+		opts.Offset = offset
+		opts.Limit = limit
+		return c.ListWebhooks(ctx, opts)
+	}
+}
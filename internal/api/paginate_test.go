@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+// newPagedTaskServer serves total tasks from /projects/p1/tasks, honouring
+// limit and a numeric offset, and records the limit of each request.
+func newPagedTaskServer(t *testing.T, total int, limits *[]int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/p1/tasks" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		start, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		*limits = append(*limits, limit)
+
+		end := start + limit
+		if end > total {
+			end = total
+		}
+
+		data := []map[string]any{}
+		for i := start; i < end; i++ {
+			data = append(data, map[string]any{"gid": fmt.Sprintf("%d", i), "name": fmt.Sprintf("Task %d", i)})
+		}
+
+		resp := map[string]any{"data": data}
+		if end < total {
+			resp["next_page"] = map[string]any{"offset": strconv.Itoa(end)}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+}
+
+func TestPaginateFollowsNextPage(t *testing.T) {
+	var limits []int
+	server := newPagedTaskServer(t, 250, &limits)
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL))
+
+	result, err := Paginate(context.Background(), PageOptions{}, TaskPages(client, TaskListOptions{Project: "p1"}))
+	if err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+
+	if len(result.Data) != 250 {
+		t.Errorf("len(Data) = %d, want 250", len(result.Data))
+	}
+	if result.NextPage != nil {
+		t.Errorf("NextPage = %+v, want nil when exhausted", result.NextPage)
+	}
+	if len(limits) != 3 {
+		t.Errorf("made %d requests, want 3", len(limits))
+	}
+	for _, l := range limits {
+		if l != MaxPageSize {
+			t.Errorf("page limit = %d, want %d", l, MaxPageSize)
+		}
+	}
+}
+
+func TestPaginateMaxItems(t *testing.T) {
+	var limits []int
+	server := newPagedTaskServer(t, 250, &limits)
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL))
+
+	result, err := Paginate(context.Background(), PageOptions{MaxItems: 130}, TaskPages(client, TaskListOptions{Project: "p1"}))
+	if err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+
+	if len(result.Data) != 130 {
+		t.Errorf("len(Data) = %d, want 130", len(result.Data))
+	}
+	if result.NextPage == nil || result.NextPage.Offset != "130" {
+		t.Errorf("NextPage = %+v, want offset 130", result.NextPage)
+	}
+	if len(limits) != 2 || limits[1] != 30 {
+		t.Errorf("limits = %v, want [100 30]", limits)
+	}
+}
+
+func TestEachPageStopsEarly(t *testing.T) {
+	calls := 0
+	fetch := func(_ context.Context, _ string, _ int) (*models.ListResponse[int], error) {
+		calls++
+		return &models.ListResponse[int]{
+			Data:     []int{calls},
+			NextPage: &models.PageInfo{Offset: strconv.Itoa(calls)},
+		}, nil
+	}
+
+	var seen []int
+	next, err := EachPage(context.Background(), PageOptions{}, fetch, func(page []int) (bool, error) {
+		seen = append(seen, page...)
+		return len(seen) < 2, nil
+	})
+	if err != nil {
+		t.Fatalf("EachPage() error = %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	if next == nil || next.Offset != "2" {
+		t.Errorf("next = %+v, want offset 2", next)
+	}
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/errors"
+)
+
+// RateLimits budgets requests client-side so bursts of concurrent calls stay
+// under Asana's quotas instead of tripping 429s. Reads (GET) and writes
+// (everything else) have separate budgets. Zero fields take the default.
+type RateLimits struct {
+	ReadsPerMinute   int
+	WritesPerMinute  int
+	ConcurrentReads  int
+	ConcurrentWrites int
+}
+
+// DefaultRateLimits split Asana's standard quota of 1500 requests a minute
+// between reads and writes, and match its limits of 50 concurrent reads and
+// 15 concurrent writes per token.
+var DefaultRateLimits = RateLimits{
+	ReadsPerMinute:   1200,
+	WritesPerMinute:  300,
+	ConcurrentReads:  50,
+	ConcurrentWrites: 15,
+}
+
+// WithRateLimits replaces the default client-side rate limits.
+func WithRateLimits(l RateLimits) Option {
+	return func(c *HTTPClient) {
+		c.reads, c.writes = l.budgets()
+	}
+}
+
+func (l RateLimits) budgets() (reads, writes *budget) {
+	d := DefaultRateLimits
+	pick := func(v, def int) int {
+		if v > 0 {
+			return v
+		}
+		return def
+	}
+	reads = newBudget("read", pick(l.ReadsPerMinute, d.ReadsPerMinute), pick(l.ConcurrentReads, d.ConcurrentReads))
+	writes = newBudget("write", pick(l.WritesPerMinute, d.WritesPerMinute), pick(l.ConcurrentWrites, d.ConcurrentWrites))
+	return reads, writes
+}
+
+// budget is a token bucket refilled at a per-minute rate, plus a cap on
+// requests in flight. It is shared by every goroutine using the client.
+type budget struct {
+	name  string
+	slots chan struct{}
+
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newBudget allows a burst of ten seconds' worth of requests.
+func newBudget(name string, perMinute, concurrent int) *budget {
+	burst := math.Max(1, float64(perMinute)/6)
+	return &budget{
+		name:   name,
+		slots:  make(chan struct{}, concurrent),
+		rate:   float64(perMinute) / 60,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes cost tokens and returns how long until they are earned. The
+// bucket may go negative, so later callers queue behind earlier ones.
+func (b *budget) reserve(cost int) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens -= float64(cost)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func (b *budget) refund(cost int) {
+	b.mu.Lock()
+	b.tokens += float64(cost)
+	b.mu.Unlock()
+}
+
+// acquire waits until cost tokens are available and a request slot is free.
+// release must be called once the response has been read.
+func (b *budget) acquire(ctx context.Context, cost int) (release func(), waited time.Duration, err error) {
+	start := time.Now()
+
+	if wait := b.reserve(cost); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			b.refund(cost)
+			return nil, 0, errors.NewNetworkError("request cancelled", ctx.Err())
+		case <-timer.C:
+		}
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, 0, errors.NewNetworkError("request cancelled", ctx.Err())
+	}
+
+	var once sync.Once
+	release = func() { once.Do(func() { <-b.slots }) }
+	return release, time.Since(start), nil
+}
+
+type budgetKey struct{}
+
+type requestBudget struct {
+	write bool
+	cost  int
+}
+
+// withBudget overrides how the next request on ctx is charged. Batch calls
+// use it because Asana counts each action against the quota, and a batch of
+// reads is a POST.
+func withBudget(ctx context.Context, write bool, cost int) context.Context {
+	return context.WithValue(ctx, budgetKey{}, requestBudget{write: write, cost: cost})
+}
+
+func (c *HTTPClient) budgetFor(ctx context.Context, method string) (*budget, int) {
+	rb, ok := ctx.Value(budgetKey{}).(requestBudget)
+	if !ok {
+		rb = requestBudget{write: method != "GET", cost: 1}
+	}
+	if rb.write {
+		return c.writes, rb.cost
+	}
+	return c.reads, rb.cost
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/errors"
+)
+
+func TestBudget_Reserve(t *testing.T) {
+	// 60 a minute is one a second, with a burst of 10.
+	b := newBudget("read", 60, 5)
+
+	for i := 0; i < 10; i++ {
+		if wait := b.reserve(1); wait != 0 {
+			t.Fatalf("reserve %d waited %s, want burst to be free", i, wait)
+		}
+	}
+	if wait := b.reserve(1); wait < 900*time.Millisecond || wait > time.Second {
+		t.Errorf("reserve past burst = %s, want about 1s", wait)
+	}
+	if wait := b.reserve(2); wait < 2900*time.Millisecond || wait > 3*time.Second {
+		t.Errorf("queued reserve = %s, want about 3s behind the earlier caller", wait)
+	}
+
+	b.refund(3)
+	if wait := b.reserve(1); wait > time.Second {
+		t.Errorf("reserve after refund = %s, want refunded tokens reused", wait)
+	}
+}
+
+func TestBudget_AcquireCancelled(t *testing.T) {
+	b := newBudget("write", 6, 1)
+	b.reserve(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := b.acquire(ctx, 1); errors.GetExitCode(err) != errors.ExitNetworkError {
+		t.Errorf("acquire() error = %v, want a cancelled network error", err)
+	}
+	if b.tokens < -0.01 {
+		t.Errorf("tokens = %v, want the cancelled reservation refunded", b.tokens)
+	}
+}
+
+func TestRateLimits_ConcurrencyAndDebug(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"data": {"gid": "1"}}`))
+	}))
+	defer server.Close()
+
+	var debug bytes.Buffer
+	var mu sync.Mutex
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL), WithDebug(&lockedWriter{w: &debug, mu: &mu}),
+		WithRateLimits(RateLimits{ReadsPerMinute: 600, ConcurrentReads: 2}))
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetMe(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("peak concurrent reads = %d, want at most 2", got)
+	}
+	// A burst of 100 covers all 12, so only the concurrency cap slows them.
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("requests took %s", elapsed)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !strings.Contains(debug.String(), "[DEBUG] Rate limiter: waited") || !strings.Contains(debug.String(), "for read budget") {
+		t.Errorf("debug output should report limiter waits:\n%s", debug.String())
+	}
+}
+
+func TestBudgetFor(t *testing.T) {
+	client := NewHTTPClient(&config.Config{})
+	ctx := context.Background()
+
+	if b, cost := client.budgetFor(ctx, http.MethodGet); b != client.reads || cost != 1 {
+		t.Errorf("GET charged to %s x%d", b.name, cost)
+	}
+	if b, cost := client.budgetFor(ctx, http.MethodPut); b != client.writes || cost != 1 {
+		t.Errorf("PUT charged to %s x%d", b.name, cost)
+	}
+	if b, cost := client.budgetFor(withBudget(ctx, false, 7), http.MethodPost); b != client.reads || cost != 7 {
+		t.Errorf("read batch charged to %s x%d, want read x7", b.name, cost)
+	}
+}
+
+type lockedWriter struct {
+	w  *bytes.Buffer
+	mu *sync.Mutex
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	stderrors "errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/errors"
+)
+
+// RetryPolicy controls how failed requests are retried.
+//
+// Rate limited requests (429) are always retried: Asana rejected them
+// without acting on them. Server errors (5xx) and dropped connections or
+// timeouts are retried only when the request is idempotent, since the
+// failed attempt may already have taken effect. A connection that could not
+// be opened never reached Asana, so that is retried for any request.
+type RetryPolicy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: time.Second,
+	MaxBackoff:     30 * time.Second,
+}
+
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *HTTPClient) {
+		c.retry = p
+	}
+}
+
+// Attempt is one failed try of a request. When a request is retried, the
+// final error carries every attempt under details.attempts.
+type Attempt struct {
+	Attempt int    `json:"attempt"`
+	Status  int    `json:"status,omitempty"`
+	Error   string `json:"error"`
+	// Wait is the delay before the next attempt; empty on the last one.
+	Wait string `json:"wait,omitempty"`
+}
+
+func withAttempts(err error, attempts []Attempt) error {
+	if len(attempts) < 2 {
+		return err
+	}
+	cliErr := errors.AsCLIError(err)
+	cliErr.Details = map[string]any{"attempts": attempts}
+	return cliErr
+}
+
+func (c *HTTPClient) shouldRetry(ctx context.Context, method, path string, status int, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch {
+	case status == http.StatusTooManyRequests:
+		return true
+	case status == http.StatusInternalServerError, status == http.StatusBadGateway,
+		status == http.StatusServiceUnavailable, status == http.StatusGatewayTimeout:
+		return idempotent(ctx, method, path)
+	case status != 0:
+		return false
+	}
+
+	if errors.GetExitCode(err) != errors.ExitNetworkError {
+		return false
+	}
+	var opErr *net.OpError
+	if stderrors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return idempotent(ctx, method, path)
+}
+
+// idempotentActions are POST endpoints that set a relationship, so repeating
+// one leaves the same result as sending it once.
+var idempotentActions = map[string]bool{
+	"addDependencies":    true,
+	"removeDependencies": true,
+	"addFollowers":       true,
+	"removeFollower":     true,
+	"addTag":             true,
+	"removeTag":          true,
+	"addProject":         true,
+	"removeProject":      true,
+	"setParent":          true,
+	"addTask":            true,
+}
+
+type idempotentKey struct{}
+
+// withIdempotent marks the next request on ctx as safe, or unsafe, to
+// repeat, overriding the rule for its method and path.
+func withIdempotent(ctx context.Context, safe bool) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, safe)
+}
+
+func idempotent(ctx context.Context, method, path string) bool {
+	if safe, ok := ctx.Value(idempotentKey{}).(bool); ok {
+		return safe
+	}
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		path, _, _ = strings.Cut(path, "?")
+		return idempotentActions[path[strings.LastIndex(path, "/")+1:]]
+	}
+	return false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return errors.NewNetworkError("request cancelled", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
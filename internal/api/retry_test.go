@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+var fastRetry = RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+// flakyServer fails the first failures requests with status, or by dropping
+// the connection when status is 0, then succeeds.
+func flakyServer(t *testing.T, failures int32, status int, header http.Header) (*HTTPClient, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			if status == 0 {
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				_ = conn.Close()
+				return
+			}
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"errors": [{"message": "try again"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"gid": "1", "name": "ok"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	return NewHTTPClient(cfg, WithBaseURL(server.URL), WithRetryPolicy(fastRetry)), &calls
+}
+
+func TestRetry_IdempotentRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		call   func(c *HTTPClient) error
+		want   int32
+	}{
+		{"GET on 502", http.StatusBadGateway, func(c *HTTPClient) error { _, err := c.GetTask(context.Background(), "1"); return err }, 3},
+		{"GET on dropped connection", 0, func(c *HTTPClient) error { _, err := c.GetTask(context.Background(), "1"); return err }, 3},
+		{"PUT on 500", http.StatusInternalServerError, func(c *HTTPClient) error {
+			name := "x"
+			_, err := c.UpdateTask(context.Background(), "1", models.TaskUpdateRequest{Name: &name})
+			return err
+		}, 3},
+		{"POST addTag on 503", http.StatusServiceUnavailable, func(c *HTTPClient) error { _, err := c.AddTag(context.Background(), "1", "2"); return err }, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, calls := flakyServer(t, 2, tt.status, nil)
+			if err := tt.call(client); err != nil {
+				t.Fatalf("error = %v, want success after retries", err)
+			}
+			if got := calls.Load(); got != tt.want {
+				t.Errorf("calls = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetry_UnsafePostNotRetried(t *testing.T) {
+	for _, status := range []int{http.StatusInternalServerError, 0} {
+		client, calls := flakyServer(t, 1, status, nil)
+		_, err := client.CreateTask(context.Background(), models.TaskCreateRequest{Name: "x", Workspace: "1"})
+		if err == nil {
+			t.Fatalf("status %d: expected the failure to be returned", status)
+		}
+		if got := calls.Load(); got != 1 {
+			t.Errorf("status %d: calls = %d, want 1 for a non-idempotent POST", status, got)
+		}
+		if errors.AsCLIError(err).Details != nil {
+			t.Errorf("status %d: details = %v, want none without retries", status, errors.AsCLIError(err).Details)
+		}
+	}
+}
+
+func TestRetry_DialFailureRetriesAnyRequest(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := NewHTTPClient(cfg, WithBaseURL(url), WithRetryPolicy(fastRetry))
+
+	_, err := client.CreateTask(context.Background(), models.TaskCreateRequest{Name: "x", Workspace: "1"})
+	if errors.GetExitCode(err) != errors.ExitNetworkError {
+		t.Fatalf("error = %v, want a network error", err)
+	}
+	attempts := errors.AsCLIError(err).Details.(map[string]any)["attempts"].([]Attempt)
+	if len(attempts) != fastRetry.MaxRetries+1 {
+		t.Errorf("attempts = %d, want %d", len(attempts), fastRetry.MaxRetries+1)
+	}
+}
+
+func TestRetry_AttemptHistory(t *testing.T) {
+	client, calls := flakyServer(t, 10, http.StatusServiceUnavailable, http.Header{"Retry-After": {"1"}})
+	client.retry.MaxRetries = 1
+
+	start := time.Now()
+	_, err := client.GetMe(context.Background())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want Retry-After honoured on 503", elapsed)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("calls = %d, want 2", got)
+	}
+
+	data, _ := json.Marshal(errors.AsCLIError(err))
+	for _, want := range []string{
+		`"attempts":[`,
+		`{"attempt":1,"status":503,"error":"API error 503: try again","wait":"1s"}`,
+		`{"attempt":2,"status":503,"error":"API error 503: try again"}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("error JSON missing %s:\n%s", want, data)
+		}
+	}
+}
+
+func TestIdempotent(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{"GET", "/tasks/1", true},
+		{"DELETE", "/tasks/1", true},
+		{"POST", "/tasks", false},
+		{"POST", "/tasks/1/stories", false},
+		{"POST", "/tasks/1/addDependencies", true},
+		{"POST", "/sections/1/addTask?opt_pretty=true", true},
+	}
+	for _, tt := range tests {
+		if got := idempotent(ctx, tt.method, tt.path); got != tt.want {
+			t.Errorf("idempotent(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+
+	if !batchIdempotent([]BatchAction{GetTaskAction("1", nil), AddTagAction("1", "2")}) {
+		t.Error("batch of reads and tag adds should be idempotent")
+	}
+	if batchIdempotent([]BatchAction{{RelativePath: "/tasks", Method: "post"}}) {
+		t.Error("batch creating a task should not be idempotent")
+	}
+}
@@ -18,6 +18,10 @@ type SearchTasksOptions struct {
 	Limit     int
 	Offset    string
 	OptFields []string
+
+	// CustomFields maps "<field_gid>.<operator>" (value, is_set, contains,
+	// less_than, greater_than) to the filter value.
+	CustomFields map[string]string
 }
 
 func (c *HTTPClient) SearchTasks(ctx context.Context, opts SearchTasksOptions) (*models.ListResponse[models.Task], error) {
@@ -50,6 +54,9 @@ func (c *HTTPClient) SearchTasks(ctx context.Context, opts SearchTasksOptions) (
 	if opts.Completed != nil {
 		params.Set("completed", fmt.Sprintf("%t", *opts.Completed))
 	}
+	for key, value := range opts.CustomFields {
+		params.Set("custom_fields."+key, value)
+	}
 	path += "?" + params.Encode()
 
 	var response struct {
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+const userOptFields = "name,email"
+
+// UserListOptions lists the members of Team if set, else of Workspace.
+type UserListOptions struct {
+	Workspace string
+	Team      string
+	Limit     int
+	Offset    string
+}
+
+func (c *HTTPClient) ListUsers(ctx context.Context, opts UserListOptions) (*models.ListResponse[models.User], error) {
+	var path string
+	switch {
+	case opts.Team != "":
+		path = fmt.Sprintf("/teams/%s/users", opts.Team)
+	case opts.Workspace != "":
+		path = fmt.Sprintf("/workspaces/%s/users", opts.Workspace)
+	default:
+		return nil, fmt.Errorf("workspace or team is required")
+	}
+
+	params := url.Values{}
+	params.Set("opt_fields", userOptFields)
+	if opts.Limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+	}
+	if opts.Offset != "" {
+		params.Set("offset", opts.Offset)
+	}
+	path += "?" + params.Encode()
+
+	var response struct {
+		Data     []models.User    `json:"data"`
+		NextPage *models.PageInfo `json:"next_page,omitempty"`
+	}
+
+	if err := c.get(ctx, path, &response); err != nil {
+		return nil, err
+	}
+
+	return &models.ListResponse[models.User]{
+		Data:     response.Data,
+		NextPage: response.NextPage,
+	}, nil
+}
+
+// GetUser accepts a GID, an email address or "me".
+func (c *HTTPClient) GetUser(ctx context.Context, gid string) (*models.User, error) {
+	var response struct {
+		Data models.User `json:"data"`
+	}
+
+	path := fmt.Sprintf("/users/%s?opt_fields=%s", url.PathEscape(gid), userOptFields)
+	if err := c.get(ctx, path, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/config"
+)
+
+func TestListUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspaces/999/users" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("opt_fields"); got != "name,email" {
+			t.Errorf("opt_fields = %q, want %q", got, "name,email")
+		}
+		_, _ = w.Write([]byte(`{"data": [{"gid": "1", "name": "Ada Lovelace", "email": "ada@example.com"}], "next_page": {"offset": "abc"}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		AccessToken: "test-token",
+		Timeout:     5 * time.Second,
+	}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL))
+
+	users, err := client.ListUsers(context.Background(), UserListOptions{Workspace: "999"})
+	if err != nil {
+		t.Fatalf("ListUsers() error = %v", err)
+	}
+	if len(users.Data) != 1 || users.Data[0].Email != "ada@example.com" {
+		t.Errorf("users = %+v, want Ada with email", users.Data)
+	}
+	if users.NextPage == nil || users.NextPage.Offset != "abc" {
+		t.Errorf("NextPage = %+v, want offset abc", users.NextPage)
+	}
+
+	if _, err := client.ListUsers(context.Background(), UserListOptions{}); err == nil {
+		t.Error("ListUsers() without workspace should fail")
+	}
+}
+
+func TestListTeamUsersAndGetUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/teams/55/users":
+			_, _ = w.Write([]byte(`{"data": [{"gid": "1", "name": "Ada Lovelace"}]}`))
+		case "/users/ada@example.com":
+			_, _ = w.Write([]byte(`{"data": {"gid": "1", "name": "Ada Lovelace", "email": "ada@example.com"}}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		AccessToken: "test-token",
+		Timeout:     5 * time.Second,
+	}
+	client := NewHTTPClient(cfg, WithBaseURL(server.URL))
+
+	users, err := client.ListUsers(context.Background(), UserListOptions{Workspace: "999", Team: "55"})
+	if err != nil {
+		t.Fatalf("ListUsers() error = %v", err)
+	}
+	if len(users.Data) != 1 {
+		t.Errorf("ListUsers() returned %d users, want 1", len(users.Data))
+	}
+
+	user, err := client.GetUser(context.Background(), "ada@example.com")
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if user.GID != "1" || user.Email != "ada@example.com" {
+		t.Errorf("GetUser() = %+v, want Ada", user)
+	}
+}
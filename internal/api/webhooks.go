@@ -0,0 +1,78 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+type WebhookListOptions struct {
+	Workspace string
+	Resource  string
+	Limit     int
+	Offset    string
+}
+
+func (c *HTTPClient) ListWebhooks(ctx context.Context, opts WebhookListOptions) (*models.ListResponse[models.Webhook], error) {
+	if opts.Workspace == "" {
+		return nil, fmt.Errorf("workspace is required")
+	}
+
+	params := url.Values{}
+	params.Set("workspace", opts.Workspace)
+	if opts.Resource != "" {
+		params.Set("resource", opts.Resource)
+	}
+	if opts.Limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+	}
+	if opts.Offset != "" {
+		params.Set("offset", opts.Offset)
+	}
+
+	var response struct {
+		Data     []models.Webhook `json:"data"`
+		NextPage *models.PageInfo `json:"next_page,omitempty"`
+	}
+
+	if err := c.get(ctx, "/webhooks?"+params.Encode(), &response); err != nil {
+		return nil, err
+	}
+
+	return &models.ListResponse[models.Webhook]{
+		Data:     response.Data,
+		NextPage: response.NextPage,
+	}, nil
+}
+
+// CreateWebhook registers a webhook. Asana performs the X-Hook-Secret
+// handshake against the target before this returns, so the receiver must
+// already be reachable.
+func (c *HTTPClient) CreateWebhook(ctx context.Context, req models.WebhookCreateRequest) (*models.Webhook, error) {
+	payload := struct {
+		Data models.WebhookCreateRequest `json:"data"`
+	}{Data: req}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	var response struct {
+		Data models.Webhook `json:"data"`
+	}
+
+	if err := c.post(ctx, "/webhooks", bytes.NewReader(body), &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+func (c *HTTPClient) DeleteWebhook(ctx context.Context, gid string) error {
+	return c.delete(ctx, "/webhooks/"+gid)
+}
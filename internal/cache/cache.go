@@ -0,0 +1,204 @@
+// Package cache stores API responses on disk with a TTL. Entries carry tags
+// (resource GIDs) so mutations can invalidate every response that mentions
+// the resources they touched.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/config"
+)
+
+const DefaultDir = "~/.cache/asana-cli"
+
+type Cache struct {
+	dir string
+	now func() time.Time
+}
+
+type entry struct {
+	Key       string          `json:"key"`
+	Tags      []string        `json:"tags,omitempty"`
+	StoredAt  time.Time       `json:"stored_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Body      json.RawMessage `json:"body"`
+}
+
+type Stats struct {
+	Dir     string `json:"dir"`
+	Entries int    `json:"entries"`
+	Expired int    `json:"expired"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// New returns a cache rooted at dir. If dir is empty, $XDG_CACHE_HOME/asana-cli
+// or DefaultDir is used.
+func New(dir string) *Cache {
+	if dir == "" {
+		if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+			dir = filepath.Join(xdg, "asana-cli")
+		} else {
+			dir = config.ExpandPath(DefaultDir)
+		}
+	}
+	return &Cache{dir: dir, now: time.Now}
+}
+
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the cached body for key if present and not expired.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	e, err := c.read(c.path(key))
+	if err != nil || e.Key != key || !c.now().Before(e.ExpiresAt) {
+		return nil, false
+	}
+	return e.Body, true
+}
+
+// Set stores body under key for ttl. Bodies must be valid JSON.
+func (c *Cache) Set(key string, body []byte, ttl time.Duration, tags []string) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	now := c.now()
+	data, err := json.Marshal(entry{
+		Key:       key,
+		Tags:      tags,
+		StoredAt:  now,
+		ExpiresAt: now.Add(ttl),
+		Body:      body,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// Invalidate removes every entry tagged with any of tags, along with any
+// expired entries found on the way. It returns the number removed.
+func (c *Cache) Invalidate(tags []string) (int, error) {
+	if len(tags) == 0 {
+		return 0, nil
+	}
+	want := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		want[t] = true
+	}
+
+	return c.remove(func(e *entry) bool {
+		if !c.now().Before(e.ExpiresAt) {
+			return true
+		}
+		for _, t := range e.Tags {
+			if want[t] {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Clear removes all entries, or only expired ones if expiredOnly is set.
+func (c *Cache) Clear(expiredOnly bool) (int, error) {
+	return c.remove(func(e *entry) bool {
+		return !expiredOnly || !c.now().Before(e.ExpiresAt)
+	})
+}
+
+func (c *Cache) Stats() (Stats, error) {
+	stats := Stats{Dir: c.dir}
+	err := c.walk(func(path string, info os.FileInfo) error {
+		stats.Entries++
+		stats.Bytes += info.Size()
+		if e, err := c.read(path); err != nil || !c.now().Before(e.ExpiresAt) {
+			stats.Expired++
+		}
+		return nil
+	})
+	return stats, err
+}
+
+func (c *Cache) remove(match func(e *entry) bool) (int, error) {
+	removed := 0
+	err := c.walk(func(path string, _ os.FileInfo) error {
+		e, err := c.read(path)
+		if err == nil && !match(e) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		removed++
+		return nil
+	})
+	return removed, err
+}
+
+func (c *Cache) walk(fn func(path string, info os.FileInfo) error) error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, de := range entries {
+		name := de.Name()
+		if de.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) != ".json" {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+		if err := fn(filepath.Join(c.dir, name), info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Cache) read(path string) (*entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetSetExpiry(t *testing.T) {
+	c := New(t.TempDir())
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	if err := c.Set("k", []byte(`{"data":1}`), time.Minute, nil); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	body, ok := c.Get("k")
+	if !ok || string(body) != `{"data":1}` {
+		t.Fatalf("Get() = %s, %v", body, ok)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Get("k"); ok {
+		t.Error("Get() returned expired entry")
+	}
+}
+
+func TestSetZeroTTLSkips(t *testing.T) {
+	c := New(t.TempDir())
+	if err := c.Set("k", []byte(`{}`), 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("k"); ok {
+		t.Error("entry with zero TTL was cached")
+	}
+}
+
+func TestInvalidateByTag(t *testing.T) {
+	c := New(t.TempDir())
+	_ = c.Set("task", []byte(`{}`), time.Minute, []string{"1"})
+	_ = c.Set("list", []byte(`{}`), time.Minute, []string{"p1", "1", "2"})
+	_ = c.Set("other", []byte(`{}`), time.Minute, []string{"3"})
+
+	n, err := c.Invalidate([]string{"1"})
+	if err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("removed %d, want 2", n)
+	}
+	if _, ok := c.Get("other"); !ok {
+		t.Error("unrelated entry was invalidated")
+	}
+}
+
+func TestClearAndStats(t *testing.T) {
+	c := New(t.TempDir())
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	_ = c.Set("a", []byte(`{}`), time.Minute, nil)
+	_ = c.Set("b", []byte(`{}`), time.Hour, nil)
+	now = now.Add(10 * time.Minute)
+
+	stats, err := c.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Entries != 2 || stats.Expired != 1 || stats.Bytes == 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	if n, _ := c.Clear(true); n != 1 {
+		t.Errorf("Clear(expired) removed %d, want 1", n)
+	}
+	if n, _ := c.Clear(false); n != 1 {
+		t.Errorf("Clear() removed %d, want 1", n)
+	}
+}
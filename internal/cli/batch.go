@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/parallel"
+)
+
+type taskAction struct {
+	TaskGID string
+	Action  api.BatchAction
+}
+
+type taskActionResult struct {
+	TaskGID string `json:"task_gid"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+// applyTaskActions submits actions through the batch API, running up to
+// concurrency batch requests at once. It reports one result per task in
+// first-seen order; a task fails if any of its actions fail.
+func applyTaskActions(ctx context.Context, client api.Client, actions []taskAction, concurrency int) ([]taskActionResult, int, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	batchResults := make([]api.BatchResult, len(actions))
+	chunks := (len(actions) + api.MaxBatchActions - 1) / api.MaxBatchActions
+
+	err := parallel.Each(ctx, chunks, concurrency, func(ctx context.Context, i int) error {
+		start := i * api.MaxBatchActions
+		end := min(start+api.MaxBatchActions, len(actions))
+
+		chunk := make([]api.BatchAction, end-start)
+		for j := range chunk {
+			chunk[j] = actions[start+j].Action
+		}
+
+		results, err := client.Batch(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		copy(batchResults[start:], results)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	index := map[string]int{}
+	var results []taskActionResult
+	for i, action := range actions {
+		pos, ok := index[action.TaskGID]
+		if !ok {
+			pos = len(results)
+			index[action.TaskGID] = pos
+			results = append(results, taskActionResult{TaskGID: action.TaskGID, Success: true})
+		}
+		if !results[pos].Success {
+			continue
+		}
+		if err := batchResults[i].Err; err != nil {
+			cliErr := errors.AsCLIError(err)
+			results[pos] = taskActionResult{TaskGID: action.TaskGID, Error: cliErr.Message, Code: cliErr.Code}
+		}
+	}
+
+	failed := 0
+	for _, r := range results {
+		if !r.Success {
+			failed++
+		}
+	}
+
+	return results, failed, nil
+}
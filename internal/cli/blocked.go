@@ -1,12 +1,15 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 
+	"github.com/whoaa512/asana-cli/internal/api"
 	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/graph"
 	"github.com/whoaa512/asana-cli/internal/models"
 	"github.com/whoaa512/asana-cli/internal/output"
 )
@@ -14,21 +17,35 @@ import (
 var blockedCmd = &cobra.Command{
 	Use:   "blocked",
 	Short: "List tasks blocked by incomplete dependencies",
-	Long:  "List tasks that are blocked by at least one incomplete dependency. Filters by project and assignee.",
-	RunE:  runBlocked,
+	Long: `List tasks that are blocked by at least one incomplete dependency. Filters by project and assignee.
+
+With --transitive, dependencies are followed (into other projects too) and
+each task gains:
+
+  blocked_by     its own incomplete dependencies
+  root_blockers  incomplete tasks at the bottom of its chains, which can be
+                 worked on now
+  chain          the gating chain from its first blocker to a root blocker,
+                 following the latest due dates (see 'critical-path')
+  depth          length of that chain`,
+	RunE: runBlocked,
 }
 
 var (
-	blockedProject  string
-	blockedAssignee string
-	blockedLimit    int
+	blockedProject    string
+	blockedAssignee   string
+	blockedLimit      int
+	blockedPages      pageFlags
+	blockedTransitive bool
 )
 
 func init() {
 	rootCmd.AddCommand(blockedCmd)
-	blockedCmd.Flags().StringVar(&blockedProject, "project", "", "Filter by project GID")
-	blockedCmd.Flags().StringVar(&blockedAssignee, "assignee", "", "Filter by assignee GID or 'me'")
+	blockedCmd.Flags().StringVar(&blockedProject, "project", "", "Filter by project GID or name")
+	blockedCmd.Flags().StringVar(&blockedAssignee, "assignee", "", "Filter by assignee GID, email, name or 'me'")
 	blockedCmd.Flags().IntVar(&blockedLimit, "limit", 20, "Max results to return")
+	addPageFlags(blockedCmd, &blockedPages)
+	blockedCmd.Flags().BoolVar(&blockedTransitive, "transitive", false, "Follow dependencies to show blocking chains and root blockers")
 }
 
 func runBlocked(_ *cobra.Command, _ []string) error {
@@ -40,39 +57,98 @@ func runBlocked(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
-	project := blockedProject
-	if project == "" && cfg.Project != "" {
-		project = cfg.Project
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	project, err := resolveProjectFlag(ctx, cfg, client, blockedProject, false)
+	if err != nil {
+		return err
 	}
 	if project == "" {
 		return errors.NewGeneralError("no project specified via --project or context", nil)
 	}
+	assignee, err := resolveUserGID(ctx, cfg, client, blockedAssignee, false)
+	if err != nil {
+		return err
+	}
 
 	if cfg.DryRun {
 		out := output.NewJSON(os.Stdout)
 		return out.Print(map[string]any{
-			"dry_run":  true,
-			"project":  project,
-			"assignee": blockedAssignee,
-			"limit":    blockedLimit,
-			"action":   "blocked",
+			"dry_run":    true,
+			"project":    project,
+			"assignee":   assignee,
+			"limit":      blockedLimit,
+			"all":        blockedPages.all,
+			"max":        blockedPages.max,
+			"transitive": blockedTransitive,
+			"action":     "blocked",
 		})
 	}
 
-	client := newClient(cfg)
-
-	incompleteTasks, err := fetchIncompleteTasksWithDeps(client, project, blockedAssignee, blockedLimit)
+	// Chains are traced through every incomplete task, so only a plain
+	// listing can stop paging once --limit tasks are found.
+	limit := blockedLimit
+	if blockedPages.all || blockedTransitive {
+		limit = 0
+	}
+	var incompleteTasks []models.Task
+	blockedTasks, err := fetchMatchingTasks(ctx, client, project, assignee, blockedPages.max, limit, func(page []models.Task) ([]models.Task, error) {
+		incompleteTasks = append(incompleteTasks, page...)
+		return filterBlockedTasks(page)
+	})
 	if err != nil {
 		return err
 	}
 
-	blockedTasks, err := filterBlockedTasks(incompleteTasks)
+	if !blockedPages.all {
+		blockedTasks = capTasks(blockedTasks, blockedLimit)
+	}
+
+	out := newOutput()
+	if !blockedTransitive {
+		return out.PrintTasks(blockedTasks)
+	}
+
+	analysed, err := analyseBlockedTasks(ctx, client, incompleteTasks, blockedTasks)
 	if err != nil {
 		return err
 	}
+	return out.Print(analysed)
+}
 
-	out := newOutput()
-	return out.PrintTasks(blockedTasks)
+type blockedTask struct {
+	models.Task
+	BlockedBy    []models.AsanaResource `json:"blocked_by"`
+	RootBlockers []models.AsanaResource `json:"root_blockers"`
+	Chain        []models.AsanaResource `json:"chain"`
+	Depth        int                    `json:"depth"`
+}
+
+// analyseBlockedTasks builds the dependency graph of tasks, follows
+// incomplete dependencies outside it, and describes what blocks each of
+// blocked.
+func analyseBlockedTasks(ctx context.Context, client api.Client, tasks, blocked []models.Task) ([]blockedTask, error) {
+	g := graph.New()
+	for _, task := range tasks {
+		g.AddTask(task, "")
+	}
+	if _, err := expandExternal(ctx, client, g, true); err != nil {
+		return nil, err
+	}
+
+	result := make([]blockedTask, 0, len(blocked))
+	for _, task := range blocked {
+		b := g.Blocking(task.GID)
+		result = append(result, blockedTask{
+			Task:         task,
+			BlockedBy:    nodeRefs(g, b.Direct),
+			RootBlockers: nodeRefs(g, b.Roots),
+			Chain:        nodeRefs(g, b.Chain),
+			Depth:        len(b.Chain),
+		})
+	}
+	return result, nil
 }
 
 func filterBlockedTasks(tasks []models.Task) ([]models.Task, error) {
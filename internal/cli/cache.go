@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/cache"
+	"github.com/whoaa512/asana-cli/internal/errors"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local response cache",
+	Long: `Read requests are cached under ~/.cache/asana-cli (or $XDG_CACHE_HOME/asana-cli)
+with per-resource TTLs: tasks and stories 1m, projects, sections, tags, teams and
+custom fields 10m, users and workspaces 1h. Mutations invalidate cached responses
+that mention the resources they touch.
+
+Use --no-cache (or ASANA_NO_CACHE=1) to bypass cached responses for one command.`,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove cached responses",
+	RunE:  runCacheClear,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache location and size",
+	RunE:  runCacheStats,
+}
+
+var cacheClearExpired bool
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cacheStatsCmd)
+
+	cacheClearCmd.Flags().BoolVar(&cacheClearExpired, "expired", false, "Only remove expired entries")
+}
+
+func runCacheClear(_ *cobra.Command, _ []string) error {
+	c := cache.New("")
+
+	if flagDryRun {
+		stats, err := c.Stats()
+		if err != nil {
+			return errors.NewGeneralError("failed to read cache", err)
+		}
+		count := stats.Entries
+		if cacheClearExpired {
+			count = stats.Expired
+		}
+		out := newOutput()
+		return out.Print(map[string]any{"dry_run": true, "dir": c.Dir(), "would_remove": count})
+	}
+
+	removed, err := c.Clear(cacheClearExpired)
+	if err != nil {
+		return errors.NewGeneralError("failed to clear cache", err)
+	}
+
+	out := newOutput()
+	return out.Print(map[string]any{"dir": c.Dir(), "removed": removed})
+}
+
+func runCacheStats(_ *cobra.Command, _ []string) error {
+	stats, err := cache.New("").Stats()
+	if err != nil {
+		return errors.NewGeneralError("failed to read cache", err)
+	}
+
+	out := newOutput()
+	return out.Print(stats)
+}
@@ -75,6 +75,9 @@ func runConfigShow(_ *cobra.Command, _ []string) error {
 		"task":               cfg.Task,
 		"timeout":            cfg.Timeout.String(),
 		"debug":              cfg.Debug,
+		"rate_limit":         cfg.RateLimit,
+		"retry":              cfg.Retry,
+		"session":            cfg.Session,
 		"config_path":        cfg.ConfigPath,
 		"config_file_found":  cfg.ConfigFileLoaded(),
 		"local_context_path": cfg.LocalContextPath,
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/graph"
+	"github.com/whoaa512/asana-cli/internal/output"
+)
+
+var criticalPathCmd = &cobra.Command{
+	Use:   "critical-path",
+	Short: "Show the dependency chains that gate each deliverable",
+	Long: `For every incomplete leaf task in a project (one no incomplete task depends
+on) that is still waiting on something, show the chain of incomplete
+dependencies that gates it, following dependencies into other projects.
+
+A task's projected finish is the latest due date among itself and its
+dependencies, transitively. At each step the chain follows the dependency
+with the latest projected finish, then the longest chain, so it shows what
+actually determines when the leaf can ship. "late" means the chain's
+projected finish is after the leaf's own due date.
+
+Longest chains are listed first.`,
+	Example: `  # What gates the release?
+  asana critical-path --project 1234567890 --limit 3`,
+	Args: cobra.NoArgs,
+	RunE: runCriticalPath,
+}
+
+var (
+	criticalPathProject string
+	criticalPathLimit   int
+)
+
+func init() {
+	rootCmd.AddCommand(criticalPathCmd)
+	criticalPathCmd.Flags().StringVar(&criticalPathProject, "project", "", "Project GID or name (default from context)")
+	criticalPathCmd.Flags().IntVar(&criticalPathLimit, "limit", 10, "Max chains to return (0 for all)")
+}
+
+type criticalPathTask struct {
+	GID             string `json:"gid"`
+	Name            string `json:"name"`
+	DueOn           string `json:"due_on,omitempty"`
+	ProjectedFinish string `json:"projected_finish,omitempty"`
+	Status          string `json:"status"`
+	External        bool   `json:"external,omitempty"`
+}
+
+type criticalPathResult struct {
+	Leaf            criticalPathTask   `json:"leaf"`
+	Length          int                `json:"length"`
+	ProjectedFinish string             `json:"projected_finish,omitempty"`
+	Late            bool               `json:"late"`
+	Tasks           []criticalPathTask `json:"tasks"`
+}
+
+func runCriticalPath(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	project, err := resolveProjectFlag(ctx, cfg, client, criticalPathProject, false)
+	if err != nil {
+		return err
+	}
+	if project == "" {
+		return errors.NewGeneralError("no project specified via --project or context", nil)
+	}
+
+	if cfg.DryRun {
+		out := output.NewJSON(os.Stdout)
+		return out.Print(map[string]any{
+			"dry_run": true,
+			"action":  "critical-path",
+			"project": project,
+			"limit":   criticalPathLimit,
+		})
+	}
+
+	g, err := fetchProjectGraph(ctx, client, project, false, true)
+	if err != nil {
+		return err
+	}
+	walk, err := expandExternal(ctx, client, g, true)
+	if err != nil {
+		return err
+	}
+
+	paths := criticalPaths(g)
+	if criticalPathLimit > 0 && len(paths) > criticalPathLimit {
+		paths = paths[:criticalPathLimit]
+	}
+
+	result := map[string]any{"project": project, "paths": paths}
+	if walk.truncated {
+		result["truncated"] = true
+	}
+
+	out := output.NewJSON(os.Stdout)
+	return out.Print(result)
+}
+
+func criticalPaths(g *graph.Graph) []criticalPathResult {
+	describe := func(gid string) criticalPathTask {
+		n := g.Node(gid)
+		return criticalPathTask{
+			GID:             n.GID,
+			Name:            n.Name,
+			DueOn:           n.DueOn,
+			ProjectedFinish: g.ProjectedFinish(gid),
+			Status:          g.Status(gid),
+			External:        n.External,
+		}
+	}
+
+	results := []criticalPathResult{}
+	for _, p := range g.CriticalPaths() {
+		tasks := make([]criticalPathTask, len(p.Tasks))
+		for i, gid := range p.Tasks {
+			tasks[i] = describe(gid)
+		}
+		results = append(results, criticalPathResult{
+			Leaf:            describe(p.Leaf),
+			Length:          len(p.Tasks),
+			ProjectedFinish: p.Finish,
+			Late:            g.Late(p),
+			Tasks:           tasks,
+		})
+	}
+	return results
+}
@@ -0,0 +1,362 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+var customFieldCmd = &cobra.Command{
+	Use:     "custom-field",
+	Aliases: []string{"cf"},
+	Short:   "Inspect custom fields",
+	Long:    "List custom field definitions, including enum options.",
+}
+
+var customFieldListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List custom fields",
+	Long: `List custom fields on a project, or in the workspace if no project is
+given or in context.`,
+	Example: `  # Custom fields on a project
+  asana custom-field list --project 1234567890
+
+  # Every custom field in the workspace
+  asana custom-field list --all`,
+	RunE: runCustomFieldList,
+}
+
+var (
+	customFieldListProject string
+	customFieldListLimit   int
+	customFieldListOffset  string
+	customFieldListPages   pageFlags
+)
+
+func init() {
+	rootCmd.AddCommand(customFieldCmd)
+	customFieldCmd.AddCommand(customFieldListCmd)
+
+	customFieldListCmd.Flags().StringVar(&customFieldListProject, "project", "", "Project GID or name")
+	customFieldListCmd.Flags().IntVar(&customFieldListLimit, "limit", 50, "Max results to return")
+	customFieldListCmd.Flags().StringVar(&customFieldListOffset, "offset", "", "Pagination offset")
+	addPageFlags(customFieldListCmd, &customFieldListPages)
+}
+
+func runCustomFieldList(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	project, err := resolveProjectFlag(ctx, cfg, client, customFieldListProject, false)
+	if err != nil {
+		return err
+	}
+
+	if project != "" {
+		opts := api.CustomFieldSettingListOptions{Project: project}
+		return printList(ctx, &customFieldListPages, customFieldListLimit, customFieldListOffset, api.CustomFieldSettingPages(client, opts))
+	}
+
+	if cfg.Workspace == "" {
+		return errors.NewGeneralError("no project or workspace specified", nil)
+	}
+	opts := api.CustomFieldListOptions{Workspace: cfg.Workspace}
+	return printList(ctx, &customFieldListPages, customFieldListLimit, customFieldListOffset, api.CustomFieldPages(client, opts))
+}
+
+// fieldArg is a parsed --field "Name=Value" flag. An empty value clears the
+// field when writing and matches unset fields when filtering.
+type fieldArg struct {
+	Name  string
+	Value string
+}
+
+func parseFieldArgs(args []string) ([]fieldArg, error) {
+	var fields []fieldArg
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, errors.NewInvalidArgsError(fmt.Sprintf("invalid --field %q, expected Name=Value", arg))
+		}
+		fields = append(fields, fieldArg{Name: name, Value: strings.TrimSpace(value)})
+	}
+	return fields, nil
+}
+
+func projectCustomFields(ctx context.Context, client api.Client, project string) ([]models.CustomField, error) {
+	result, err := api.Paginate(ctx, api.PageOptions{}, api.CustomFieldSettingPages(client, api.CustomFieldSettingListOptions{Project: project}))
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]models.CustomField, len(result.Data))
+	for i, setting := range result.Data {
+		fields[i] = setting.CustomField
+	}
+	return fields, nil
+}
+
+func workspaceCustomFields(ctx context.Context, client api.Client, workspace string) ([]models.CustomField, error) {
+	result, err := api.Paginate(ctx, api.PageOptions{}, api.CustomFieldPages(client, api.CustomFieldListOptions{Workspace: workspace}))
+	if err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+func findCustomField(fields []models.CustomField, nameOrGID string) (*models.CustomField, error) {
+	for i := range fields {
+		if fields[i].GID == nameOrGID || strings.EqualFold(fields[i].Name, nameOrGID) {
+			return &fields[i], nil
+		}
+	}
+
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	msg := fmt.Sprintf("custom field %q not found", nameOrGID)
+	if len(names) > 0 {
+		msg += "; available: " + strings.Join(names, ", ")
+	}
+	return nil, errors.NewInvalidArgsError(msg)
+}
+
+func findEnumOption(field *models.CustomField, nameOrGID string) (string, error) {
+	for _, opt := range field.EnumOptions {
+		if opt.GID == nameOrGID || strings.EqualFold(opt.Name, nameOrGID) {
+			return opt.GID, nil
+		}
+	}
+
+	names := make([]string, len(field.EnumOptions))
+	for i, opt := range field.EnumOptions {
+		names[i] = opt.Name
+	}
+	return "", errors.NewInvalidArgsError(fmt.Sprintf("%q is not an option of %s; available: %s", nameOrGID, field.Name, strings.Join(names, ", ")))
+}
+
+func splitList(value string) []string {
+	var parts []string
+	for _, p := range strings.Split(value, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
+// userResolver maps a user name, email or GID to a GID.
+type userResolver func(nameOrGID string) (string, error)
+
+// fieldUserResolver resolves people field values with resolveUserGID.
+func fieldUserResolver(ctx context.Context, cfg *config.Config, client api.Client) userResolver {
+	return func(nameOrGID string) (string, error) {
+		return resolveUserGID(ctx, cfg, client, nameOrGID, false)
+	}
+}
+
+// customFieldValue converts a raw flag value to the JSON value Asana expects
+// for the field's type. Enum options may be given by name or GID, and people
+// by name, email or GID.
+func customFieldValue(field *models.CustomField, raw string, users userResolver) (any, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	switch field.ResourceSubtype {
+	case "enum":
+		return findEnumOption(field, raw)
+	case "multi_enum":
+		var gids []string
+		for _, name := range splitList(raw) {
+			gid, err := findEnumOption(field, name)
+			if err != nil {
+				return nil, err
+			}
+			gids = append(gids, gid)
+		}
+		return gids, nil
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, errors.NewInvalidArgsError(fmt.Sprintf("%s must be a number, got %q", field.Name, raw))
+		}
+		return n, nil
+	case "date":
+		if _, err := time.Parse("2006-01-02", raw); err != nil {
+			return nil, errors.NewInvalidArgsError(fmt.Sprintf("%s must be a date (YYYY-MM-DD), got %q", field.Name, raw))
+		}
+		return map[string]string{"date": raw}, nil
+	case "people":
+		var gids []string
+		for _, person := range splitList(raw) {
+			gid, err := users(person)
+			if err != nil {
+				return nil, err
+			}
+			gids = append(gids, gid)
+		}
+		return gids, nil
+	default:
+		return raw, nil
+	}
+}
+
+// resolveCustomFieldValues builds the custom_fields payload for a task
+// create or update from --field flags.
+func resolveCustomFieldValues(fields []models.CustomField, args []fieldArg, users userResolver) (map[string]any, error) {
+	values := map[string]any{}
+	for _, arg := range args {
+		field, err := findCustomField(fields, arg.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := customFieldValue(field, arg.Value, users)
+		if err != nil {
+			return nil, err
+		}
+		values[field.GID] = value
+	}
+	return values, nil
+}
+
+// searchCustomFieldParams maps --field filters to search API parameters.
+func searchCustomFieldParams(fields []models.CustomField, args []fieldArg, users userResolver) (map[string]string, error) {
+	params := map[string]string{}
+	for _, arg := range args {
+		field, err := findCustomField(fields, arg.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if arg.Value == "" {
+			params[field.GID+".is_set"] = "false"
+			continue
+		}
+		if field.ResourceSubtype == "text" {
+			params[field.GID+".contains"] = arg.Value
+			continue
+		}
+
+		value, err := customFieldValue(field, arg.Value, users)
+		if err != nil {
+			return nil, err
+		}
+		switch v := value.(type) {
+		case []string:
+			params[field.GID+".value"] = strings.Join(v, ",")
+		case float64:
+			params[field.GID+".value"] = strconv.FormatFloat(v, 'f', -1, 64)
+		case map[string]string:
+			params[field.GID+".value"] = v["date"]
+		default:
+			params[field.GID+".value"] = fmt.Sprint(v)
+		}
+	}
+	return params, nil
+}
+
+// taskMatchesFields reports whether a task's custom field values satisfy
+// every filter. Text fields match by substring, others by name or GID.
+func taskMatchesFields(task models.Task, args []fieldArg) bool {
+	for _, arg := range args {
+		field, err := findCustomField(task.CustomFields, arg.Name)
+		if err != nil || !customFieldMatches(field, arg.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func customFieldMatches(field *models.CustomField, raw string) bool {
+	if raw == "" {
+		return !customFieldIsSet(field)
+	}
+
+	matchesOption := func(opt models.EnumOption) bool {
+		for _, want := range splitList(raw) {
+			if opt.GID == want || strings.EqualFold(opt.Name, want) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch field.ResourceSubtype {
+	case "enum":
+		return field.EnumValue != nil && matchesOption(*field.EnumValue)
+	case "multi_enum":
+		for _, opt := range field.MultiEnumValues {
+			if matchesOption(opt) {
+				return true
+			}
+		}
+		return false
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		return err == nil && field.NumberValue != nil && *field.NumberValue == n
+	case "date":
+		return field.DateValue != nil && field.DateValue.Date == raw
+	case "people":
+		for _, person := range field.PeopleValue {
+			for _, want := range splitList(raw) {
+				if person.GID == want || strings.EqualFold(person.Name, want) {
+					return true
+				}
+			}
+		}
+		return false
+	default:
+		value := field.TextValue
+		if value == nil {
+			value = field.DisplayValue
+		}
+		return value != nil && strings.Contains(strings.ToLower(*value), strings.ToLower(raw))
+	}
+}
+
+func customFieldIsSet(field *models.CustomField) bool {
+	return field.EnumValue != nil ||
+		len(field.MultiEnumValues) > 0 ||
+		field.NumberValue != nil ||
+		(field.TextValue != nil && *field.TextValue != "") ||
+		field.DateValue != nil ||
+		len(field.PeopleValue) > 0
+}
+
+// filterTaskPages drops tasks that don't match the --field filters. Pages
+// may come back short; pagination keeps going until --limit tasks match.
+func filterTaskPages(fetch api.PageFunc[models.Task], args []fieldArg) api.PageFunc[models.Task] {
+	return func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Task], error) {
+		page, err := fetch(ctx, offset, limit)
+		if err != nil {
+			return nil, err
+		}
+		matched := []models.Task{}
+		for _, task := range page.Data {
+			if taskMatchesFields(task, args) {
+				matched = append(matched, task)
+			}
+		}
+		page.Data = matched
+		return page, nil
+	}
+}
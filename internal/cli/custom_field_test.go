@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+func testCustomFields() []models.CustomField {
+	return []models.CustomField{
+		{
+			GID:             "cf1",
+			Name:            "Priority",
+			ResourceSubtype: "enum",
+			EnumOptions:     []models.EnumOption{{GID: "o1", Name: "High"}, {GID: "o2", Name: "Low"}},
+		},
+		{
+			GID:             "cf2",
+			Name:            "Labels",
+			ResourceSubtype: "multi_enum",
+			EnumOptions:     []models.EnumOption{{GID: "o3", Name: "Backend"}, {GID: "o4", Name: "Frontend"}},
+		},
+		{GID: "cf3", Name: "Estimate", ResourceSubtype: "number"},
+		{GID: "cf4", Name: "Launch", ResourceSubtype: "date"},
+		{GID: "cf5", Name: "Notes", ResourceSubtype: "text"},
+		{GID: "cf6", Name: "Reviewers", ResourceSubtype: "people"},
+	}
+}
+
+// testUsers resolves the names Ann and Bob, and passes GIDs through.
+func testUsers(nameOrGID string) (string, error) {
+	switch nameOrGID {
+	case "Ann":
+		return "u1", nil
+	case "Bob":
+		return "u2", nil
+	case "u3":
+		return nameOrGID, nil
+	}
+	return "", fmt.Errorf("no users found matching '%s'", nameOrGID)
+}
+
+func TestResolveCustomFieldValues(t *testing.T) {
+	args, err := parseFieldArgs([]string{
+		"priority=high",
+		"Labels=Backend, Frontend",
+		"Estimate=2.5",
+		"Launch=2024-06-01",
+		"Notes=hello",
+		"Reviewers=Ann, u3",
+	})
+	if err != nil {
+		t.Fatalf("parseFieldArgs() error = %v", err)
+	}
+
+	got, err := resolveCustomFieldValues(testCustomFields(), args, testUsers)
+	if err != nil {
+		t.Fatalf("resolveCustomFieldValues() error = %v", err)
+	}
+
+	want := map[string]any{
+		"cf1": "o1",
+		"cf2": []string{"o3", "o4"},
+		"cf3": 2.5,
+		"cf4": map[string]string{"date": "2024-06-01"},
+		"cf5": "hello",
+		"cf6": []string{"u1", "u3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("values = %#v, want %#v", got, want)
+	}
+}
+
+func TestResolveCustomFieldValuesErrors(t *testing.T) {
+	tests := []string{
+		"Missing=1",
+		"Priority=Urgent",
+		"Estimate=lots",
+		"Launch=tomorrow",
+		"Reviewers=Nobody",
+	}
+
+	for _, arg := range tests {
+		t.Run(arg, func(t *testing.T) {
+			args, err := parseFieldArgs([]string{arg})
+			if err != nil {
+				t.Fatalf("parseFieldArgs() error = %v", err)
+			}
+			if _, err := resolveCustomFieldValues(testCustomFields(), args, testUsers); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	if _, err := parseFieldArgs([]string{"Priority"}); err == nil {
+		t.Error("expected error for missing '='")
+	}
+}
+
+func TestSearchCustomFieldParams(t *testing.T) {
+	args, err := parseFieldArgs([]string{"Priority=Low", "Notes=design", "Reviewers=Ann,Bob", "Launch="})
+	if err != nil {
+		t.Fatalf("parseFieldArgs() error = %v", err)
+	}
+
+	got, err := searchCustomFieldParams(testCustomFields(), args, testUsers)
+	if err != nil {
+		t.Fatalf("searchCustomFieldParams() error = %v", err)
+	}
+
+	want := map[string]string{
+		"cf1.value":    "o2",
+		"cf5.contains": "design",
+		"cf6.value":    "u1,u2",
+		"cf4.is_set":   "false",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("params = %v, want %v", got, want)
+	}
+}
+
+func TestTaskMatchesFields(t *testing.T) {
+	estimate := 3.0
+	text := "Needs design review"
+	task := models.Task{
+		GID: "1",
+		CustomFields: []models.CustomField{
+			{GID: "cf1", Name: "Priority", ResourceSubtype: "enum", EnumValue: &models.EnumOption{GID: "o1", Name: "High"}},
+			{GID: "cf3", Name: "Estimate", ResourceSubtype: "number", NumberValue: &estimate},
+			{GID: "cf4", Name: "Launch", ResourceSubtype: "date"},
+			{GID: "cf5", Name: "Notes", ResourceSubtype: "text", TextValue: &text},
+		},
+	}
+
+	tests := []struct {
+		filters []string
+		want    bool
+	}{
+		{[]string{"Priority=High"}, true},
+		{[]string{"Priority=Low"}, false},
+		{[]string{"Priority=High", "Estimate=3"}, true},
+		{[]string{"Estimate=4"}, false},
+		{[]string{"Launch="}, true},
+		{[]string{"Notes=design"}, true},
+		{[]string{"Unknown=x"}, false},
+	}
+
+	for _, tt := range tests {
+		args, err := parseFieldArgs(tt.filters)
+		if err != nil {
+			t.Fatalf("parseFieldArgs() error = %v", err)
+		}
+		if got := taskMatchesFields(task, args); got != tt.want {
+			t.Errorf("taskMatchesFields(%v) = %v, want %v", tt.filters, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/graph"
+	"github.com/whoaa512/asana-cli/internal/models"
+	"github.com/whoaa512/asana-cli/internal/output"
+)
+
+var depCmd = &cobra.Command{
+	Use:   "dep",
+	Short: "Analyse task dependencies",
+	Long:  "Analyse dependencies across a project. Add and remove dependencies with 'task dep'.",
+}
+
+var depCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Report dependency cycles and stale dependencies",
+	Long: `Walk every dependency reachable from a project's incomplete tasks, following
+dependencies into other projects, and report:
+
+  cycles                  tasks that transitively depend on themselves
+  dangling                dependencies on tasks that no longer exist
+  completed_dependencies  dependencies on completed tasks, safe to prune
+
+Exits 1 when cycles or dangling dependencies are found. With --prune,
+dependencies on completed tasks are removed (respects --dry-run).`,
+	Args: cobra.NoArgs,
+	RunE: runDepCheck,
+}
+
+var (
+	depCheckProject string
+	depCheckPrune   bool
+)
+
+func init() {
+	rootCmd.AddCommand(depCmd)
+	depCmd.AddCommand(depCheckCmd)
+
+	depCheckCmd.Flags().StringVar(&depCheckProject, "project", "", "Project GID or name (default from context)")
+	depCheckCmd.Flags().BoolVar(&depCheckPrune, "prune", false, "Remove dependencies on completed tasks")
+}
+
+// maxDependencyWalk bounds how many tasks a walk fetches, so a densely
+// linked workspace cannot turn one command into thousands of requests.
+const maxDependencyWalk = 2000
+
+var dependencyOptFields = []string{"name", "completed", "due_on", "dependencies.name", "dependencies.completed"}
+
+type dependencyWalk struct {
+	expanded  map[string]bool
+	missing   map[string]bool
+	truncated bool
+}
+
+// walkDependencies fetches tasks breadth-first from start, adding each
+// one's dependencies to g, until done reports true, nothing is left to
+// fetch, or maxDependencyWalk tasks have been fetched. Tasks in skip are
+// treated as already expanded. With incompleteOnly, completed dependencies
+// are recorded but not walked into.
+func walkDependencies(ctx context.Context, client api.Client, g *graph.Graph, start []string, skip map[string]bool, incompleteOnly bool, done func() bool) (*dependencyWalk, error) {
+	walk := &dependencyWalk{expanded: map[string]bool{}, missing: map[string]bool{}}
+	for gid := range skip {
+		walk.expanded[gid] = true
+	}
+
+	frontier := start
+	for len(frontier) > 0 && !done() {
+		var actions []api.BatchAction
+		for _, gid := range frontier {
+			if walk.expanded[gid] {
+				continue
+			}
+			if len(walk.expanded) >= maxDependencyWalk+len(skip) {
+				walk.truncated = true
+				break
+			}
+			walk.expanded[gid] = true
+			actions = append(actions, api.GetTaskAction(gid, dependencyOptFields))
+		}
+		frontier = nil
+		if len(actions) == 0 {
+			break
+		}
+
+		results, err := client.Batch(ctx, actions)
+		if err != nil {
+			return nil, err
+		}
+		for i, r := range results {
+			gid := actionTaskGID(actions[i])
+			var task models.Task
+			if err := r.Decode(&task); err != nil {
+				if errors.GetExitCode(err) == errors.ExitNotFound {
+					walk.missing[gid] = true
+					continue
+				}
+				return nil, err
+			}
+
+			var deps []models.Task
+			if task.Dependencies != nil {
+				deps = *task.Dependencies
+			}
+			g.SetDependencies(gid, deps)
+			n := g.Node(gid)
+			n.Name = task.Name
+			n.Completed = task.Completed
+			n.DueOn = task.DueOn
+
+			for _, dep := range deps {
+				if incompleteOnly && dep.Completed {
+					continue
+				}
+				if !walk.expanded[dep.GID] {
+					frontier = append(frontier, dep.GID)
+				}
+			}
+		}
+	}
+	return walk, nil
+}
+
+// expandExternal walks onward from g's external nodes, so chains that leave
+// the project through another project's tasks are followed.
+func expandExternal(ctx context.Context, client api.Client, g *graph.Graph, incompleteOnly bool) (*dependencyWalk, error) {
+	skip := map[string]bool{}
+	var external []string
+	for _, n := range g.Nodes() {
+		switch {
+		case !n.External:
+			skip[n.GID] = true
+		case incompleteOnly && n.Completed:
+		default:
+			external = append(external, n.GID)
+		}
+	}
+	return walkDependencies(ctx, client, g, external, skip, incompleteOnly, func() bool { return false })
+}
+
+func actionTaskGID(action api.BatchAction) string {
+	return action.RelativePath[len("/tasks/"):]
+}
+
+// findDependencyCycle reports the cycle that making taskGID depend on
+// dependsOnGID would close: taskGID -> dependsOnGID -> ... -> taskGID.
+func findDependencyCycle(ctx context.Context, client api.Client, taskGID, dependsOnGID string) ([]models.AsanaResource, error) {
+	if taskGID == dependsOnGID {
+		return []models.AsanaResource{{GID: taskGID}, {GID: taskGID}}, nil
+	}
+
+	g := graph.New()
+	walk, err := walkDependencies(ctx, client, g, []string{dependsOnGID}, nil, false, func() bool {
+		return g.Node(taskGID) != nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	path := g.Path(dependsOnGID, taskGID)
+	if path == nil {
+		if walk.truncated {
+			return nil, errors.NewGeneralError(fmt.Sprintf("dependency graph too large to check (over %d tasks), use --skip-check to add anyway", maxDependencyWalk), nil)
+		}
+		return nil, nil
+	}
+	return nodeRefs(g, append([]string{taskGID}, path...)), nil
+}
+
+func nodeRefs(g *graph.Graph, gids []string) []models.AsanaResource {
+	refs := make([]models.AsanaResource, len(gids))
+	for i, gid := range gids {
+		refs[i] = models.AsanaResource{GID: gid}
+		if n := g.Node(gid); n != nil {
+			refs[i].Name = n.Name
+		}
+	}
+	return refs
+}
+
+type dependencyEdge struct {
+	Task      models.AsanaResource `json:"task"`
+	DependsOn models.AsanaResource `json:"depends_on"`
+}
+
+type dependencyReport struct {
+	Project               string                   `json:"project"`
+	Tasks                 int                      `json:"tasks"`
+	Cycles                [][]models.AsanaResource `json:"cycles"`
+	Dangling              []dependencyEdge         `json:"dangling"`
+	CompletedDependencies []dependencyEdge         `json:"completed_dependencies"`
+	Truncated             bool                     `json:"truncated,omitempty"`
+}
+
+// checkDependencies analyses g after walk. Project tasks are the non-external
+// nodes; only their completed dependencies are reported for pruning.
+func checkDependencies(g *graph.Graph, walk *dependencyWalk) dependencyReport {
+	report := dependencyReport{
+		Cycles:                [][]models.AsanaResource{},
+		Dangling:              []dependencyEdge{},
+		CompletedDependencies: []dependencyEdge{},
+		Truncated:             walk.truncated,
+	}
+
+	for _, cycle := range g.Cycles() {
+		report.Cycles = append(report.Cycles, nodeRefs(g, cycle))
+	}
+
+	for _, n := range g.Nodes() {
+		if !n.External {
+			report.Tasks++
+		}
+		for _, dep := range n.DependsOn {
+			edge := dependencyEdge{Task: models.AsanaResource{GID: n.GID, Name: n.Name}, DependsOn: nodeRefs(g, []string{dep})[0]}
+			switch {
+			case walk.missing[dep]:
+				report.Dangling = append(report.Dangling, edge)
+			case !n.External && !n.Completed && g.Node(dep).Completed:
+				report.CompletedDependencies = append(report.CompletedDependencies, edge)
+			}
+		}
+	}
+	return report
+}
+
+func runDepCheck(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	project, err := resolveProjectFlag(ctx, cfg, client, depCheckProject, false)
+	if err != nil {
+		return err
+	}
+	if project == "" {
+		return errors.NewGeneralError("no project specified via --project or context", nil)
+	}
+
+	g, err := fetchProjectGraph(ctx, client, project, false, true)
+	if err != nil {
+		return err
+	}
+
+	walk, err := expandExternal(ctx, client, g, false)
+	if err != nil {
+		return err
+	}
+
+	report := checkDependencies(g, walk)
+	report.Project = project
+
+	result := map[string]any{"report": report}
+	if depCheckPrune && len(report.CompletedDependencies) > 0 {
+		if cfg.DryRun {
+			result["dry_run"] = true
+			result["would_prune"] = report.CompletedDependencies
+		} else {
+			pruned, failed := pruneDependencies(ctx, client, report.CompletedDependencies)
+			result["pruned"] = pruned
+			if len(failed) > 0 {
+				result["prune_failed"] = failed
+			}
+		}
+	}
+
+	out := output.NewJSON(os.Stdout)
+	if err := out.Print(result); err != nil {
+		return err
+	}
+	if len(report.Cycles) > 0 || len(report.Dangling) > 0 || result["prune_failed"] != nil {
+		return &reportedError{exitCode: errors.ExitGeneral}
+	}
+	return nil
+}
+
+func pruneDependencies(ctx context.Context, client api.Client, edges []dependencyEdge) ([]dependencyEdge, []map[string]any) {
+	var pruned []dependencyEdge
+	var failed []map[string]any
+	for _, e := range edges {
+		if err := client.RemoveDependency(ctx, e.Task.GID, e.DependsOn.GID); err != nil {
+			failed = append(failed, map[string]any{"task": e.Task, "depends_on": e.DependsOn, "error": err.Error()})
+			continue
+		}
+		pruned = append(pruned, e)
+	}
+	return pruned, failed
+}
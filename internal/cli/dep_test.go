@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/graph"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+// dependencyServer answers batched GET /tasks/<gid> actions from tasks;
+// unknown GIDs get a 404 like deleted tasks do.
+func dependencyServer(t *testing.T, tasks map[string]string) api.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Data struct {
+				Actions []api.BatchAction `json:"actions"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		var results []string
+		for _, action := range req.Data.Actions {
+			gid := strings.TrimPrefix(action.RelativePath, "/tasks/")
+			if body, ok := tasks[gid]; ok {
+				results = append(results, `{"status_code": 200, "body": {"data": `+body+`}}`)
+			} else {
+				results = append(results, `{"status_code": 404, "body": {"errors": [{"message": "Unknown object"}]}}`)
+			}
+		}
+		_, _ = w.Write([]byte(`{"data": [` + strings.Join(results, ",") + `]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	return api.NewHTTPClient(cfg, api.WithBaseURL(server.URL))
+}
+
+func TestFindDependencyCycle(t *testing.T) {
+	client := dependencyServer(t, map[string]string{
+		"2": `{"gid": "2", "name": "B", "dependencies": [{"gid": "3", "name": "C"}]}`,
+		"3": `{"gid": "3", "name": "C", "dependencies": [{"gid": "1", "name": "A"}]}`,
+		"1": `{"gid": "1", "name": "A", "dependencies": []}`,
+	})
+	ctx := context.Background()
+
+	cycle, err := findDependencyCycle(ctx, client, "1", "2")
+	if err != nil {
+		t.Fatalf("findDependencyCycle() error = %v", err)
+	}
+	want := []models.AsanaResource{{GID: "1", Name: "A"}, {GID: "2", Name: "B"}, {GID: "3", Name: "C"}, {GID: "1", Name: "A"}}
+	if !reflect.DeepEqual(cycle, want) {
+		t.Errorf("cycle = %+v, want %+v", cycle, want)
+	}
+
+	cycle, err = findDependencyCycle(ctx, client, "3", "1")
+	if err != nil || cycle != nil {
+		t.Errorf("findDependencyCycle(3, 1) = %+v, %v; want no cycle", cycle, err)
+	}
+
+	cycle, _ = findDependencyCycle(ctx, client, "7", "7")
+	if len(cycle) != 2 {
+		t.Errorf("self dependency cycle = %+v", cycle)
+	}
+}
+
+func TestCheckDependencies(t *testing.T) {
+	client := dependencyServer(t, map[string]string{
+		"10": `{"gid": "10", "name": "Other project", "dependencies": [{"gid": "1", "name": "A"}]}`,
+		"11": `{"gid": "11", "name": "Shipped", "completed": true, "dependencies": []}`,
+	})
+
+	deps := func(tasks ...models.Task) *[]models.Task { return &tasks }
+	g := graph.New()
+	g.AddTask(models.Task{GID: "1", Name: "A", Dependencies: deps(models.Task{GID: "10"}, models.Task{GID: "11", Completed: true})}, "")
+	g.AddTask(models.Task{GID: "2", Name: "B", Dependencies: deps(models.Task{GID: "99", Name: "Deleted"})}, "")
+
+	walk, err := walkDependencies(context.Background(), client, g, []string{"10", "11", "99"}, map[string]bool{"1": true, "2": true}, false, func() bool { return false })
+	if err != nil {
+		t.Fatalf("walkDependencies() error = %v", err)
+	}
+
+	report := checkDependencies(g, walk)
+
+	if report.Tasks != 2 {
+		t.Errorf("tasks = %d, want 2", report.Tasks)
+	}
+	if len(report.Cycles) != 1 || report.Cycles[0][1].GID != "10" {
+		t.Errorf("cycles = %+v, want 1 -> 10 -> 1", report.Cycles)
+	}
+	if len(report.Dangling) != 1 || report.Dangling[0].DependsOn.GID != "99" {
+		t.Errorf("dangling = %+v", report.Dangling)
+	}
+	if len(report.CompletedDependencies) != 1 || report.CompletedDependencies[0].DependsOn.GID != "11" {
+		t.Errorf("completed dependencies = %+v", report.CompletedDependencies)
+	}
+}
+
+func TestAnalyseBlockedTasks(t *testing.T) {
+	client := dependencyServer(t, map[string]string{
+		"10": `{"gid": "10", "name": "Vendor API", "due_on": "2026-04-01", "dependencies": [{"gid": "11", "name": "Contract"}]}`,
+		"11": `{"gid": "11", "name": "Contract", "dependencies": []}`,
+	})
+
+	deps := func(tasks ...models.Task) *[]models.Task { return &tasks }
+	tasks := []models.Task{
+		{GID: "1", Name: "Integrate", Dependencies: deps(models.Task{GID: "10", Name: "Vendor API"})},
+		{GID: "2", Name: "Launch", Dependencies: deps(models.Task{GID: "1", Name: "Integrate"})},
+	}
+
+	got, err := analyseBlockedTasks(context.Background(), client, tasks, tasks[1:])
+	if err != nil {
+		t.Fatalf("analyseBlockedTasks() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d results", len(got))
+	}
+	b := got[0]
+	if len(b.BlockedBy) != 1 || b.BlockedBy[0].GID != "1" {
+		t.Errorf("blocked_by = %+v", b.BlockedBy)
+	}
+	if len(b.RootBlockers) != 1 || b.RootBlockers[0] != (models.AsanaResource{GID: "11", Name: "Contract"}) {
+		t.Errorf("root_blockers = %+v, want the contract in the other project", b.RootBlockers)
+	}
+	if b.Depth != 3 {
+		t.Errorf("depth = %d, want 3 (Integrate -> Vendor API -> Contract)", b.Depth)
+	}
+}
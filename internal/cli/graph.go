@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/graph"
+	"github.com/whoaa512/asana-cli/internal/models"
+	"github.com/whoaa512/asana-cli/internal/output"
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export a project's dependency graph",
+	Long: `Walk a project's tasks, their subtasks and dependencies, and print the graph.
+
+Nodes are grouped by section and coloured by status: green for ready,
+red for blocked (an incomplete direct dependency, as in 'asana blocked'),
+grey for completed. Dependencies outside the project are drawn dashed.
+Edges point from the blocking task to the task it blocks; subtasks hang
+off their parent with an undirected dashed edge.`,
+	Example: `  # Mermaid for a PR description or design doc
+  asana graph --project 1234567890 --format mermaid
+
+  # Render with Graphviz
+  asana graph --format dot | dot -Tsvg > deps.svg`,
+	Args: cobra.NoArgs,
+	RunE: runGraph,
+}
+
+var (
+	graphProject          string
+	graphFormat           string
+	graphIncludeCompleted bool
+	graphNoSubtasks       bool
+)
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+	graphCmd.Flags().StringVar(&graphProject, "project", "", "Project GID or name (default from context)")
+	graphCmd.Flags().StringVar(&graphFormat, "format", "json", "Output format: json, dot, mermaid")
+	graphCmd.Flags().BoolVar(&graphIncludeCompleted, "include-completed", false, "Include completed tasks (completed dependencies are always shown)")
+	graphCmd.Flags().BoolVar(&graphNoSubtasks, "no-subtasks", false, "Only walk top-level project tasks")
+}
+
+// graphOptFields fetches what a node needs: status, section and the compact
+// form of each dependency.
+var graphOptFields = []string{
+	"name", "completed", "due_on", "parent", "num_subtasks",
+	"memberships.project", "memberships.section.name",
+	"dependencies.name", "dependencies.completed",
+}
+
+// subtaskBatchLimit caps the subtasks read per parent in one batch action;
+// larger parents are read over several rounds.
+const subtaskBatchLimit = 100
+
+func runGraph(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	switch graphFormat {
+	case "json", "dot", "mermaid":
+	default:
+		return errors.NewInvalidArgsError(fmt.Sprintf("invalid --format %q, must be json, dot, or mermaid", graphFormat))
+	}
+
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	project, err := resolveProjectFlag(ctx, cfg, client, graphProject, false)
+	if err != nil {
+		return err
+	}
+	if project == "" {
+		return errors.NewGeneralError("no project specified via --project or context", nil)
+	}
+
+	if cfg.DryRun {
+		out := output.NewJSON(os.Stdout)
+		return out.Print(map[string]any{
+			"dry_run":           true,
+			"action":            "graph",
+			"project":           project,
+			"format":            graphFormat,
+			"include_completed": graphIncludeCompleted,
+			"subtasks":          !graphNoSubtasks,
+		})
+	}
+
+	g, err := fetchProjectGraph(ctx, client, project, graphIncludeCompleted, !graphNoSubtasks)
+	if err != nil {
+		return err
+	}
+
+	switch graphFormat {
+	case "dot":
+		return g.WriteDOT(os.Stdout)
+	case "mermaid":
+		return g.WriteMermaid(os.Stdout)
+	}
+
+	doc := g.Document()
+	out := output.NewJSON(os.Stdout)
+	return out.Print(map[string]any{"project": project, "nodes": doc.Nodes, "edges": doc.Edges})
+}
+
+// fetchProjectGraph builds the graph of a project's tasks. Subtasks are
+// fetched level by level through the batch API and placed in their parent's
+// section.
+func fetchProjectGraph(ctx context.Context, client api.Client, project string, includeCompleted, withSubtasks bool) (*graph.Graph, error) {
+	opts := api.TaskListOptions{Project: project, OptFields: graphOptFields}
+	if !includeCompleted {
+		completed := false
+		opts.Completed = &completed
+	}
+
+	result, err := api.Paginate(ctx, api.PageOptions{}, api.TaskPages(client, opts))
+	if err != nil {
+		return nil, err
+	}
+
+	g := graph.New()
+	var parents []string
+	for _, task := range result.Data {
+		section := ""
+		if s := task.SectionIn(project); s != nil {
+			section = s.Name
+		}
+		g.AddTask(task, section)
+		if task.NumSubtasks > 0 {
+			parents = append(parents, task.GID)
+		}
+	}
+
+	visited := map[string]bool{}
+	// Each round reads the first page of new parents' subtasks and the next
+	// page of any parent with more than one.
+	type subtaskPage struct{ parent, offset string }
+	var more []subtaskPage
+	for withSubtasks && (len(parents) > 0 || len(more) > 0) {
+		pages := more
+		more = nil
+		for _, gid := range parents {
+			if visited[gid] {
+				continue
+			}
+			visited[gid] = true
+			pages = append(pages, subtaskPage{parent: gid})
+		}
+		parents = nil
+
+		actions := make([]api.BatchAction, len(pages))
+		for i, p := range pages {
+			actions[i] = api.ListSubtasksAction(p.parent, graphOptFields, subtaskBatchLimit, p.offset)
+		}
+
+		results, err := client.Batch(ctx, actions)
+		if err != nil {
+			return nil, err
+		}
+		for i, r := range results {
+			var subtasks []models.Task
+			next, err := r.DecodePage(&subtasks)
+			if err != nil {
+				return nil, err
+			}
+			if next != nil && next.Offset != "" {
+				more = append(more, subtaskPage{parent: pages[i].parent, offset: next.Offset})
+			}
+			for _, sub := range subtasks {
+				if sub.Completed && !includeCompleted {
+					continue
+				}
+				// Subtasks also homed in the project keep their own section.
+				if n := g.Node(sub.GID); n != nil && !n.External {
+					continue
+				}
+				section := ""
+				if sub.Parent != nil {
+					if p := g.Node(sub.Parent.GID); p != nil {
+						section = p.Section
+					}
+				}
+				g.AddTask(sub, section)
+				if sub.NumSubtasks > 0 {
+					parents = append(parents, sub.GID)
+				}
+			}
+		}
+	}
+
+	return g, nil
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/graph"
+)
+
+func TestFetchProjectGraph(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/P/tasks":
+			_, _ = w.Write([]byte(`{"data": [
+				{"gid": "1", "name": "Parent", "num_subtasks": 1, "dependencies": [],
+				 "memberships": [{"project": {"gid": "P"}, "section": {"gid": "S", "name": "Doing"}}]},
+				{"gid": "2", "name": "Next", "dependencies": [{"gid": "3", "name": "Sub", "completed": false}],
+				 "memberships": [{"project": {"gid": "P"}, "section": {"gid": "T", "name": "Todo"}}]}
+			]}`))
+		case "/batch":
+			var req struct {
+				Data struct {
+					Actions []api.BatchAction `json:"actions"`
+				} `json:"data"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if len(req.Data.Actions) != 1 || req.Data.Actions[0].RelativePath != "/tasks/1/subtasks" {
+				t.Errorf("batch actions = %+v", req.Data.Actions)
+			}
+			_, _ = w.Write([]byte(`{"data": [{"status_code": 200, "body": {"data": [
+				{"gid": "3", "name": "Sub", "parent": {"gid": "1"}, "dependencies": []},
+				{"gid": "4", "name": "Old sub", "completed": true, "parent": {"gid": "1"}, "dependencies": []}
+			]}}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := api.NewHTTPClient(cfg, api.WithBaseURL(server.URL))
+
+	g, err := fetchProjectGraph(context.Background(), client, "P", false, true)
+	if err != nil {
+		t.Fatalf("fetchProjectGraph() error = %v", err)
+	}
+
+	sub := g.Node("3")
+	if sub == nil || sub.External || sub.Section != "Doing" || sub.Parent != "1" {
+		t.Errorf("subtask node = %+v, want full node in parent's section", sub)
+	}
+	if g.Node("4") != nil {
+		t.Error("completed subtask should be skipped")
+	}
+	if got := g.Status("2"); got != graph.StatusBlocked {
+		t.Errorf("Status(2) = %s, want blocked", got)
+	}
+}
+
+func TestFetchProjectGraphPagesSubtasks(t *testing.T) {
+	rounds := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/P/tasks":
+			_, _ = w.Write([]byte(`{"data": [{"gid": "1", "name": "Parent", "num_subtasks": 2, "dependencies": []}]}`))
+		case "/batch":
+			var req struct {
+				Data struct {
+					Actions []api.BatchAction `json:"actions"`
+				} `json:"data"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			rounds++
+			if rounds == 1 {
+				_, _ = w.Write([]byte(`{"data": [{"status_code": 200, "body": {"data": [
+					{"gid": "3", "name": "Sub A", "parent": {"gid": "1"}, "dependencies": []}
+				], "next_page": {"offset": "page2"}}}]}`))
+				return
+			}
+			if len(req.Data.Actions) != 1 || req.Data.Actions[0].Options["offset"] != "page2" {
+				t.Errorf("second round actions = %+v, want the next page of task 1", req.Data.Actions)
+			}
+			_, _ = w.Write([]byte(`{"data": [{"status_code": 200, "body": {"data": [
+				{"gid": "4", "name": "Sub B", "parent": {"gid": "1"}, "dependencies": []}
+			]}}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := api.NewHTTPClient(cfg, api.WithBaseURL(server.URL))
+
+	g, err := fetchProjectGraph(context.Background(), client, "P", false, true)
+	if err != nil {
+		t.Fatalf("fetchProjectGraph() error = %v", err)
+	}
+	if g.Node("3") == nil || g.Node("4") == nil {
+		t.Error("expected subtasks from both pages")
+	}
+	if rounds != 2 {
+		t.Errorf("batch rounds = %d, want 2", rounds)
+	}
+}
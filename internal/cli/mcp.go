@@ -0,0 +1,523 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/mcp"
+	"github.com/whoaa512/asana-cli/internal/models"
+	"github.com/whoaa512/asana-cli/internal/output"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Model Context Protocol server",
+	Long:  "Expose asana-cli commands as tools to MCP-capable agents.",
+}
+
+var mcpServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve MCP over stdio",
+	Long: `Speak the Model Context Protocol over stdin/stdout, exposing these tools:
+
+  task_get, task_list, task_create, task_update
+  session_start, session_log, session_end
+  ready, blocked, prime
+
+Configuration and .asana.json context are resolved on every call from the
+directory the server was started in, so 'ctx' changes take effect without a
+restart. Global flags such as --workspace and --dry-run apply to all calls.`,
+	Example: `  # Register with an MCP client
+  {"mcpServers": {"asana": {"command": "asana", "args": ["mcp", "serve"]}}}`,
+	Args: cobra.NoArgs,
+	RunE: runMCPServe,
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+	mcpCmd.AddCommand(mcpServeCmd)
+}
+
+func runMCPServe(_ *cobra.Command, _ []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := newMCPServer()
+	return server.Serve(ctx, os.Stdin, os.Stdout)
+}
+
+func newMCPServer() *mcp.Server {
+	server := mcp.NewServer("asana-cli", cliVersion)
+	server.FormatError = func(err error) string {
+		var buf bytes.Buffer
+		_ = output.NewJSON(&buf).PrintError(err)
+		return buf.String()
+	}
+	for _, tool := range mcpTools() {
+		server.AddTool(tool)
+	}
+	return server
+}
+
+// mcpTool loads config and decodes arguments before calling fn, so each
+// tool sees the same context resolution as the equivalent command.
+func mcpTool[A any](name, description string, schema *mcp.Schema, fn func(ctx context.Context, cfg *config.Config, args A) (any, error)) mcp.Tool {
+	return mcp.Tool{
+		Name:        name,
+		Description: description,
+		InputSchema: schema,
+		Handler: func(ctx context.Context, raw json.RawMessage) (any, error) {
+			var args A
+			if err := json.Unmarshal(raw, &args); err != nil {
+				return nil, errors.NewInvalidArgsError("invalid arguments: " + err.Error())
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				return nil, err
+			}
+			return fn(ctx, cfg, args)
+		},
+	}
+}
+
+func stringProp(description string) *mcp.Schema {
+	return &mcp.Schema{Type: "string", Description: description}
+}
+
+func objectSchema(props map[string]*mcp.Schema, required ...string) *mcp.Schema {
+	return &mcp.Schema{Type: "object", Properties: props, Required: required}
+}
+
+var mcpFieldsProp = &mcp.Schema{
+	Type:        "array",
+	Description: `Custom field values as "Name=Value", e.g. "Priority=High"`,
+	Items:       &mcp.Schema{Type: "string"},
+}
+
+type mcpListArgs struct {
+	Project  string `json:"project"`
+	Assignee string `json:"assignee"`
+	Limit    int    `json:"limit"`
+}
+
+var mcpListProps = map[string]*mcp.Schema{
+	"project":  stringProp("Project GID or name (default from context)"),
+	"assignee": stringProp("Assignee GID, email, name or 'me'"),
+	"limit":    {Type: "integer", Description: "Max results to return (default 20)"},
+}
+
+func mcpTools() []mcp.Tool {
+	return []mcp.Tool{
+		mcpTool("task_get", "Get a task by GID or name.",
+			objectSchema(map[string]*mcp.Schema{
+				"task": stringProp("Task GID or name (fuzzy matched against recent tasks)"),
+			}, "task"),
+			mcpTaskGet),
+		mcpTool("task_list", "List tasks in a project, tag, or workspace.",
+			objectSchema(map[string]*mcp.Schema{
+				"project":   stringProp("Project GID or name (default from context)"),
+				"assignee":  stringProp("Assignee GID, email, name or 'me'"),
+				"tag":       stringProp("Tag GID or name"),
+				"completed": {Type: "boolean", Description: "Only completed (true) or incomplete (false) tasks"},
+				"fields":    mcpFieldsProp,
+				"limit":     {Type: "integer", Description: "Max results to return (default 50)"},
+				"offset":    stringProp("Pagination offset from a previous next_page"),
+			}),
+			mcpTaskList),
+		mcpTool("task_create", "Create a task in the context project unless project or parent is given.",
+			objectSchema(map[string]*mcp.Schema{
+				"name":     stringProp("Task name"),
+				"notes":    stringProp("Task description"),
+				"project":  stringProp("Project GID or name (default from context)"),
+				"assignee": stringProp("Assignee GID, email, name or 'me'"),
+				"due_on":   stringProp("Due date (YYYY-MM-DD)"),
+				"parent":   stringProp("Parent task GID, to create a subtask"),
+				"fields":   mcpFieldsProp,
+			}, "name"),
+			mcpTaskCreate),
+		mcpTool("task_update", "Update a task. Only the given fields change.",
+			objectSchema(map[string]*mcp.Schema{
+				"task":      stringProp("Task GID or name"),
+				"name":      stringProp("New task name"),
+				"notes":     stringProp("New description"),
+				"assignee":  stringProp("New assignee GID, email, name or 'me'"),
+				"due_on":    stringProp("New due date (YYYY-MM-DD)"),
+				"completed": {Type: "boolean", Description: "Mark complete or incomplete"},
+				"fields":    mcpFieldsProp,
+			}, "task"),
+			mcpTaskUpdate),
+		mcpTool("session_start", "Start a work session on a task; it also becomes the context task.",
+			objectSchema(map[string]*mcp.Schema{
+				"task_gid": stringProp("Task GID (default context task)"),
+				"force":    {Type: "boolean", Description: "Discard an existing session"},
+			}),
+			mcpSessionStart),
+		mcpTool("session_log", "Record a note in the current session. Notes are posted when the session ends.",
+			objectSchema(map[string]*mcp.Schema{
+				"message": stringProp("Note text"),
+				"type":    {Type: "string", Description: "Log type (default progress)", Enum: []string{"progress", "decision", "blocker"}},
+			}, "message"),
+			mcpSessionLog),
+		mcpTool("session_pause", "Pause the current session so the time until session_resume is not counted as work.",
+			objectSchema(map[string]*mcp.Schema{}),
+			mcpSessionPause),
+		mcpTool("session_resume", "Resume a paused session.",
+			objectSchema(map[string]*mcp.Schema{}),
+			mcpSessionResume),
+		mcpTool("session_end", "End the current session and post its summary to the task as a comment.",
+			objectSchema(map[string]*mcp.Schema{
+				"summary": stringProp("Additional summary text"),
+				"discard": {Type: "boolean", Description: "End without posting"},
+			}),
+			mcpSessionEnd),
+		mcpTool("ready", "List incomplete tasks whose dependencies are all complete.",
+			objectSchema(mcpListProps),
+			mcpReady),
+		mcpTool("blocked", "List incomplete tasks waiting on an incomplete dependency.",
+			objectSchema(mcpListProps),
+			mcpBlocked),
+		mcpTool("prime", "Markdown summary of the active session, ready and blocked tasks.",
+			objectSchema(map[string]*mcp.Schema{
+				"project":           stringProp("Project GID or name (default from context)"),
+				"limit":             {Type: "integer", Description: "Max tasks per section (default 20)"},
+				"include_completed": {Type: "boolean", Description: "Include recently completed tasks"},
+				"format":            {Type: "string", Description: "Output format (default markdown)", Enum: []string{"markdown", "json"}},
+				"max_tokens":        {Type: "integer", Description: "Approximate token budget to trim the output to"},
+			}),
+			mcpPrime),
+	}
+}
+
+func mcpTaskGet(ctx context.Context, cfg *config.Config, args struct {
+	Task string `json:"task"`
+}) (any, error) {
+	if err := requireAuth(cfg); err != nil {
+		return nil, err
+	}
+	if args.Task == "" {
+		return nil, errors.NewInvalidArgsError("task is required")
+	}
+
+	client := newClient(cfg)
+	gid, err := resolveTaskGID(ctx, cfg, client, args.Task, false)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetTask(ctx, gid)
+}
+
+func mcpTaskList(ctx context.Context, cfg *config.Config, args struct {
+	Project   string   `json:"project"`
+	Assignee  string   `json:"assignee"`
+	Tag       string   `json:"tag"`
+	Completed *bool    `json:"completed"`
+	Fields    []string `json:"fields"`
+	Limit     int      `json:"limit"`
+	Offset    string   `json:"offset"`
+}) (any, error) {
+	if err := requireAuth(cfg); err != nil {
+		return nil, err
+	}
+
+	client := newClient(cfg)
+	project, err := resolveProjectGID(ctx, cfg, client, args.Project, false)
+	if err != nil {
+		return nil, err
+	}
+	assignee, err := resolveUserGID(ctx, cfg, client, args.Assignee, false)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := resolveTagGID(ctx, cfg, client, args.Tag, false)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := api.TaskListOptions{
+		Project:   project,
+		Assignee:  assignee,
+		Tag:       tag,
+		Completed: args.Completed,
+	}
+	if opts.Tag == "" {
+		if opts.Project == "" {
+			opts.Project = cfg.Project
+		}
+		if opts.Project == "" {
+			if cfg.Workspace == "" {
+				return nil, errors.NewGeneralError("no project, tag, or workspace specified", nil)
+			}
+			opts.Workspace = cfg.Workspace
+		}
+	}
+
+	fields, err := parseFieldArgs(args.Fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 {
+		opts.OptFields = append([]string{"name", "completed"}, api.TaskCustomFieldOptFields...)
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	fetch := api.TaskPages(client, opts)
+	if len(fields) > 0 {
+		fetch = filterTaskPages(fetch, fields)
+	}
+	return api.Paginate(ctx, api.PageOptions{Offset: args.Offset, PageSize: limit, MaxItems: limit}, fetch)
+}
+
+func mcpTaskCreate(ctx context.Context, cfg *config.Config, args struct {
+	Name     string   `json:"name"`
+	Notes    string   `json:"notes"`
+	Project  string   `json:"project"`
+	Assignee string   `json:"assignee"`
+	DueOn    string   `json:"due_on"`
+	Parent   string   `json:"parent"`
+	Fields   []string `json:"fields"`
+}) (any, error) {
+	if err := requireAuth(cfg); err != nil {
+		return nil, err
+	}
+	if args.Name == "" {
+		return nil, errors.NewInvalidArgsError("name is required")
+	}
+
+	client := newClient(cfg)
+	project, err := resolveProjectFlag(ctx, cfg, client, args.Project, false)
+	if err != nil {
+		return nil, err
+	}
+	assignee, err := resolveUserGID(ctx, cfg, client, args.Assignee, false)
+	if err != nil {
+		return nil, err
+	}
+
+	req := models.TaskCreateRequest{
+		Name:     args.Name,
+		Notes:    args.Notes,
+		Assignee: assignee,
+		DueOn:    args.DueOn,
+		Parent:   args.Parent,
+	}
+
+	if project != "" {
+		req.Projects = []string{project}
+	}
+	if req.Parent == "" && req.Projects == nil {
+		if cfg.Workspace == "" {
+			return nil, errors.NewGeneralError("no project, parent, or workspace specified", nil)
+		}
+		req.Workspace = cfg.Workspace
+	}
+
+	fields, err := parseFieldArgs(args.Fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 {
+		if project == "" {
+			return nil, errors.NewInvalidArgsError("fields requires a project to resolve custom fields")
+		}
+		definitions, err := projectCustomFields(ctx, client, project)
+		if err != nil {
+			return nil, err
+		}
+		req.CustomFields, err = resolveCustomFieldValues(definitions, fields, fieldUserResolver(ctx, cfg, client))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.DryRun {
+		return map[string]any{"dry_run": true, "request": req}, nil
+	}
+	return client.CreateTask(ctx, req)
+}
+
+func mcpTaskUpdate(ctx context.Context, cfg *config.Config, args struct {
+	Task      string   `json:"task"`
+	Name      *string  `json:"name"`
+	Notes     *string  `json:"notes"`
+	Assignee  *string  `json:"assignee"`
+	DueOn     *string  `json:"due_on"`
+	Completed *bool    `json:"completed"`
+	Fields    []string `json:"fields"`
+}) (any, error) {
+	if err := requireAuth(cfg); err != nil {
+		return nil, err
+	}
+	if args.Task == "" {
+		return nil, errors.NewInvalidArgsError("task is required")
+	}
+
+	client := newClient(cfg)
+	gid, err := resolveTaskGID(ctx, cfg, client, args.Task, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Assignee != nil && *args.Assignee != "" {
+		assignee, err := resolveUserGID(ctx, cfg, client, *args.Assignee, false)
+		if err != nil {
+			return nil, err
+		}
+		args.Assignee = &assignee
+	}
+
+	req := models.TaskUpdateRequest{
+		Name:      args.Name,
+		Notes:     args.Notes,
+		Assignee:  args.Assignee,
+		DueOn:     args.DueOn,
+		Completed: args.Completed,
+	}
+
+	fields, err := parseFieldArgs(args.Fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 {
+		current, err := client.GetTask(ctx, gid)
+		if err != nil {
+			return nil, err
+		}
+		req.CustomFields, err = resolveCustomFieldValues(current.CustomFields, fields, fieldUserResolver(ctx, cfg, client))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.DryRun {
+		return map[string]any{"dry_run": true, "gid": gid, "request": req}, nil
+	}
+	return client.UpdateTask(ctx, gid, req)
+}
+
+func mcpSessionStart(_ context.Context, _ *config.Config, args struct {
+	TaskGID string `json:"task_gid"`
+	Force   bool   `json:"force"`
+}) (any, error) {
+	return startSession(args.TaskGID, args.Force)
+}
+
+func mcpSessionLog(_ context.Context, cfg *config.Config, args struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}) (any, error) {
+	if args.Message == "" {
+		return nil, errors.NewInvalidArgsError("message is required")
+	}
+	if args.Type == "" {
+		args.Type = "progress"
+	}
+	return logSession(cfg, args.Type, args.Message)
+}
+
+func mcpSessionPause(_ context.Context, cfg *config.Config, _ struct{}) (any, error) {
+	return pauseSession(cfg, true)
+}
+
+func mcpSessionResume(_ context.Context, cfg *config.Config, _ struct{}) (any, error) {
+	return pauseSession(cfg, false)
+}
+
+func mcpSessionEnd(_ context.Context, cfg *config.Config, args struct {
+	Summary string `json:"summary"`
+	Discard bool   `json:"discard"`
+}) (any, error) {
+	if err := requireAuth(cfg); err != nil {
+		return nil, err
+	}
+	return endSession(cfg, args.Summary, args.Discard)
+}
+
+func mcpReady(ctx context.Context, cfg *config.Config, args mcpListArgs) (any, error) {
+	return mcpMatchingTasks(ctx, cfg, args, filterReadyTasks)
+}
+
+func mcpBlocked(ctx context.Context, cfg *config.Config, args mcpListArgs) (any, error) {
+	return mcpMatchingTasks(ctx, cfg, args, filterBlockedTasks)
+}
+
+// mcpMatchingTasks pages through the project's incomplete tasks until limit
+// of them pass filter, as the ready and blocked commands do.
+func mcpMatchingTasks(ctx context.Context, cfg *config.Config, args mcpListArgs, filter func([]models.Task) ([]models.Task, error)) ([]models.Task, error) {
+	if err := requireAuth(cfg); err != nil {
+		return nil, err
+	}
+	client := newClient(cfg)
+	project, err := resolveProjectFlag(ctx, cfg, client, args.Project, false)
+	if err != nil {
+		return nil, err
+	}
+	if project == "" {
+		return nil, errors.NewGeneralError("no project specified via project argument or context", nil)
+	}
+	assignee, err := resolveUserGID(ctx, cfg, client, args.Assignee, false)
+	if err != nil {
+		return nil, err
+	}
+	limit := mcpLimit(args.Limit)
+	tasks, err := fetchMatchingTasks(ctx, client, project, assignee, 0, limit, filter)
+	if err != nil {
+		return nil, err
+	}
+	return capTasks(tasks, limit), nil
+}
+
+func mcpPrime(ctx context.Context, cfg *config.Config, args struct {
+	Project          string `json:"project"`
+	Limit            int    `json:"limit"`
+	IncludeCompleted bool   `json:"include_completed"`
+	Format           string `json:"format"`
+	MaxTokens        int    `json:"max_tokens"`
+}) (any, error) {
+	if err := requireAuth(cfg); err != nil {
+		return nil, err
+	}
+	if args.Format == "" {
+		args.Format = "markdown"
+	}
+	if args.Format != "markdown" && args.Format != "json" {
+		return nil, errors.NewInvalidArgsError(fmt.Sprintf("invalid format %q, must be markdown or json", args.Format))
+	}
+	client := newClient(cfg)
+	project, err := resolveProjectFlag(ctx, cfg, client, args.Project, false)
+	if err != nil {
+		return nil, err
+	}
+	if project == "" {
+		return nil, errors.NewGeneralError("no project specified via project argument or context", nil)
+	}
+	return buildPrime(ctx, client, primeOptions{
+		Project:          project,
+		Limit:            mcpLimit(args.Limit),
+		IncludeCompleted: args.IncludeCompleted,
+		Format:           args.Format,
+		MaxTokens:        args.MaxTokens,
+		UserName:         userNamer(cfg, client),
+		IdleTimeout:      sessionIdleTimeout(cfg),
+	})
+}
+
+func mcpLimit(limit int) int {
+	if limit <= 0 {
+		return 20
+	}
+	return limit
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/errors"
+)
+
+func TestMCPTools_Schemas(t *testing.T) {
+	seen := map[string]bool{}
+	for _, tool := range mcpTools() {
+		if seen[tool.Name] {
+			t.Errorf("duplicate tool %q", tool.Name)
+		}
+		seen[tool.Name] = true
+
+		if tool.InputSchema == nil || tool.InputSchema.Type != "object" {
+			t.Errorf("%s: input schema must be an object", tool.Name)
+			continue
+		}
+		for _, name := range tool.InputSchema.Required {
+			if _, ok := tool.InputSchema.Properties[name]; !ok {
+				t.Errorf("%s: required property %q is not defined", tool.Name, name)
+			}
+		}
+	}
+
+	for _, name := range []string{"task_get", "task_list", "task_create", "task_update", "session_start", "session_log", "session_end", "ready", "blocked", "prime"} {
+		if !seen[name] {
+			t.Errorf("missing tool %q", name)
+		}
+	}
+}
+
+func TestMCPPrimeRejectsUnknownFormat(t *testing.T) {
+	cfg := &config.Config{AccessToken: "test-token", Project: "1"}
+	_, err := mcpPrime(context.Background(), cfg, struct {
+		Project          string `json:"project"`
+		Limit            int    `json:"limit"`
+		IncludeCompleted bool   `json:"include_completed"`
+		Format           string `json:"format"`
+		MaxTokens        int    `json:"max_tokens"`
+	}{Format: "html"})
+	if errors.GetExitCode(err) != errors.ExitInvalidArgs {
+		t.Errorf("mcpPrime(format=html) error = %v, want an invalid args error", err)
+	}
+}
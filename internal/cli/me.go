@@ -48,6 +48,9 @@ var (
 	meTasksLimit     int
 	meTasksOffset    string
 	meTasksCompleted bool
+	meTeamsPages     pageFlags
+	meProjectsPages  pageFlags
+	meTasksPages     pageFlags
 )
 
 func init() {
@@ -58,12 +61,15 @@ func init() {
 
 	meTeamsCmd.Flags().IntVar(&meTeamsLimit, "limit", 50, "Max results to return")
 	meTeamsCmd.Flags().StringVar(&meTeamsOffset, "offset", "", "Pagination offset")
+	addPageFlags(meTeamsCmd, &meTeamsPages)
 
 	meProjectsCmd.Flags().IntVar(&meProjectsLimit, "limit", 50, "Max results to return")
 	meProjectsCmd.Flags().StringVar(&meProjectsOffset, "offset", "", "Pagination offset")
+	addPageFlags(meProjectsCmd, &meProjectsPages)
 
 	meTasksCmd.Flags().IntVar(&meTasksLimit, "limit", 50, "Max results to return")
 	meTasksCmd.Flags().StringVar(&meTasksOffset, "offset", "", "Pagination offset")
+	addPageFlags(meTasksCmd, &meTasksPages)
 	meTasksCmd.Flags().BoolVar(&meTasksCompleted, "completed", false, "Show completed instead of incomplete tasks")
 }
 
@@ -103,18 +109,10 @@ func runMeTeams(_ *cobra.Command, _ []string) error {
 	opts := api.UserTeamListOptions{
 		UserGID:      "me",
 		Organization: cfg.Workspace,
-		Limit:        meTeamsLimit,
-		Offset:       meTeamsOffset,
 	}
 
 	client := newClient(cfg)
-	result, err := client.ListUserTeams(context.Background(), opts)
-	if err != nil {
-		return err
-	}
-
-	out := output.NewJSON(os.Stdout)
-	return out.Print(result)
+	return printList(context.Background(), &meTeamsPages, meTeamsLimit, meTeamsOffset, api.UserTeamPages(client, opts))
 }
 
 func runMeProjects(_ *cobra.Command, _ []string) error {
@@ -132,12 +130,10 @@ func runMeProjects(_ *cobra.Command, _ []string) error {
 
 	opts := api.UserProjectListOptions{
 		Workspace: cfg.Workspace,
-		Limit:     meProjectsLimit,
-		Offset:    meProjectsOffset,
 	}
 
 	client := newClient(cfg)
-	result, err := client.ListUserProjects(context.Background(), opts)
+	result, err := listPages(context.Background(), &meProjectsPages, meProjectsLimit, meProjectsOffset, api.UserProjectPages(client, opts))
 	if err != nil {
 		return err
 	}
@@ -164,17 +160,9 @@ func runMeTasks(_ *cobra.Command, _ []string) error {
 	opts := api.TaskListOptions{
 		Workspace: cfg.Workspace,
 		Assignee:  "me",
-		Limit:     meTasksLimit,
-		Offset:    meTasksOffset,
 		Completed: &meTasksCompleted,
 	}
 
 	client := newClient(cfg)
-	result, err := client.ListTasks(context.Background(), opts)
-	if err != nil {
-		return err
-	}
-
-	out := newOutput()
-	return out.PrintTaskList(result)
+	return printList(context.Background(), &meTasksPages, meTasksLimit, meTasksOffset, api.TaskPages(client, opts))
 }
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/output"
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the highest scoring ready task",
+	Long: `Rank ready tasks as 'ready --sort score' does and return only the top one,
+with its score breakdown. Exits 4 when no task is ready.`,
+	Example: `  # What should I work on?
+  asana next --assignee me`,
+	Args: cobra.NoArgs,
+	RunE: runNext,
+}
+
+var (
+	nextProject  string
+	nextAssignee string
+)
+
+func init() {
+	rootCmd.AddCommand(nextCmd)
+	nextCmd.Flags().StringVar(&nextProject, "project", "", "Project GID or name (default from context)")
+	nextCmd.Flags().StringVar(&nextAssignee, "assignee", "", "Filter by assignee GID, email, name or 'me'")
+}
+
+func runNext(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	project, err := resolveProjectFlag(ctx, cfg, client, nextProject, false)
+	if err != nil {
+		return err
+	}
+	if project == "" {
+		return errors.NewGeneralError("no project specified via --project or context", nil)
+	}
+	assignee, err := resolveUserGID(ctx, cfg, client, nextAssignee, false)
+	if err != nil {
+		return err
+	}
+
+	if cfg.DryRun {
+		out := output.NewJSON(os.Stdout)
+		return out.Print(map[string]any{
+			"dry_run":  true,
+			"action":   "next",
+			"project":  project,
+			"assignee": assignee,
+		})
+	}
+
+	tasks, err := fetchIncompleteTasks(ctx, client, project, assignee, 0, scoreTaskFields)
+	if err != nil {
+		return err
+	}
+	ready, err := filterReadyTasks(tasks)
+	if err != nil {
+		return err
+	}
+
+	ranked, err := rankReadyTasks(ctx, cfg, client, project, ready)
+	if err != nil {
+		return err
+	}
+	if len(ranked) == 0 {
+		return errors.NewNotFoundError("ready task")
+	}
+
+	out := newOutput()
+	return out.Print(ranked[0])
+}
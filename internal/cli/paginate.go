@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/models"
+	"github.com/whoaa512/asana-cli/internal/output"
+)
+
+type pageFlags struct {
+	all bool
+	max int
+}
+
+func addPageFlags(cmd *cobra.Command, p *pageFlags) {
+	cmd.Flags().BoolVar(&p.all, "all", false, "Follow pagination and return every result")
+	cmd.Flags().IntVar(&p.max, "max", 0, "Stop paginating after this many results (implies --all)")
+}
+
+func (p *pageFlags) enabled() bool {
+	return p.all || p.max > 0
+}
+
+// listPages returns up to limit results, or every page when --all/--max is
+// set. Short pages (e.g. from client-side filters) are topped up from the
+// next page so limit is honoured either way.
+func listPages[T any](ctx context.Context, p *pageFlags, limit int, offset string, fetch api.PageFunc[T]) (*models.ListResponse[T], error) {
+	if !p.enabled() {
+		return api.Paginate(ctx, api.PageOptions{Offset: offset, PageSize: limit, MaxItems: limit}, fetch)
+	}
+	return api.Paginate(ctx, api.PageOptions{Offset: offset, MaxItems: p.max}, fetch)
+}
+
+// printList fetches and prints a list. Streaming formats write items as each
+// page arrives so output starts immediately and memory stays flat.
+func printList[T any](ctx context.Context, p *pageFlags, limit int, offset string, fetch api.PageFunc[T]) error {
+	out := newOutput()
+	stream, ok := out.(output.Streamer)
+	if !ok {
+		result, err := listPages(ctx, p, limit, offset, fetch)
+		if err != nil {
+			return err
+		}
+		return out.Print(result)
+	}
+
+	opts := api.PageOptions{Offset: offset, MaxItems: p.max}
+	if !p.enabled() {
+		opts = api.PageOptions{Offset: offset, PageSize: limit, MaxItems: limit}
+	}
+
+	_, err := api.EachPage(ctx, opts, fetch, func(page []T) (bool, error) {
+		for _, item := range page {
+			if err := stream.WriteItem(item); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	})
+	return err
+}
+
+// capTasks trims tasks to limit. A limit of zero keeps everything.
+func capTasks(tasks []models.Task, limit int) []models.Task {
+	if limit > 0 && len(tasks) > limit {
+		return tasks[:limit]
+	}
+	return tasks
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/whoaa512/asana-cli/internal/api"
 	"github.com/whoaa512/asana-cli/internal/errors"
 	"github.com/whoaa512/asana-cli/internal/models"
+	"github.com/whoaa512/asana-cli/internal/output"
+	"github.com/whoaa512/asana-cli/internal/parallel"
 	"github.com/whoaa512/asana-cli/internal/session"
 )
 
@@ -24,10 +26,21 @@ Includes:
 - Active session (if exists)
 - Ready tasks (unblocked)
 - Blocked tasks
-- Task subtasks (1 level)
+- Task notes and subtasks (1 level)
 - Recent activity (last 24h)
 
-Output is raw markdown to stdout for easy piping to AI agents.`,
+Output is raw markdown to stdout for easy piping to AI agents, or the same
+sections as structured data with --format json.
+
+--max-tokens trims the output to an approximate token budget (about four
+characters per token). Content is dropped lowest priority first: completed
+tasks, task notes, subtasks, comments, blocked tasks, older session logs and
+finally ready tasks. The active session and top ready task are always kept.`,
+	Example: `  # Fit the context into roughly 2000 tokens
+  asana prime --max-tokens 2000
+
+  # Structured sections for tooling
+  asana prime --format json`,
 	RunE: runPrime,
 }
 
@@ -35,13 +48,85 @@ var (
 	primeProject          string
 	primeLimit            int
 	primeIncludeCompleted bool
+	primeFormat           string
+	primeMaxTokens        int
 )
 
 func init() {
 	rootCmd.AddCommand(primeCmd)
-	primeCmd.Flags().StringVar(&primeProject, "project", "", "Override project GID (default from context)")
+	primeCmd.Flags().StringVar(&primeProject, "project", "", "Override project GID or name (default from context)")
 	primeCmd.Flags().IntVar(&primeLimit, "limit", 20, "Max tasks to show per section")
 	primeCmd.Flags().BoolVar(&primeIncludeCompleted, "include-completed", false, "Show recently completed tasks")
+	primeCmd.Flags().StringVar(&primeFormat, "format", "markdown", "Output format: markdown, json")
+	primeCmd.Flags().IntVar(&primeMaxTokens, "max-tokens", 0, "Approximate token budget (0 for no limit)")
+}
+
+// primeNoteLen caps each task's notes before any token budget applies.
+const primeNoteLen = 200
+
+type primeOptions struct {
+	Project          string
+	Limit            int
+	IncludeCompleted bool
+	Format           string
+	MaxTokens        int
+	// UserName names comment authors the API returned only a GID for.
+	UserName func(ctx context.Context, gid string) string
+	// IdleTimeout pauses an idle session before its time is reported.
+	IdleTimeout time.Duration
+}
+
+// primeContext is everything prime reports. It is gathered before rendering
+// so the markdown and JSON forms share it and --max-tokens can trim it.
+type primeContext struct {
+	Project   string        `json:"project"`
+	Session   *primeSession `json:"session,omitempty"`
+	Ready     []primeTask   `json:"ready"`
+	Blocked   []primeTask   `json:"blocked"`
+	Completed []models.Task `json:"completed,omitempty"`
+	Omitted   *primeOmitted `json:"omitted,omitempty"`
+}
+
+type primeSession struct {
+	TaskGID        string             `json:"task_gid"`
+	TaskName       string             `json:"task_name"`
+	StartedAt      time.Time          `json:"started_at"`
+	Duration       string             `json:"duration"`
+	Elapsed        string             `json:"elapsed"`
+	Paused         bool               `json:"paused,omitempty"`
+	StartBranch    string             `json:"start_branch,omitempty"`
+	CurrentBranch  string             `json:"current_branch,omitempty"`
+	Logs           []session.LogEntry `json:"logs"`
+	RecentComments []primeComment     `json:"recent_comments"`
+}
+
+type primeComment struct {
+	CreatedAt time.Time `json:"created_at"`
+	By        string    `json:"by"`
+	Text      string    `json:"text"`
+}
+
+type primeTask struct {
+	GID       string                 `json:"gid"`
+	Name      string                 `json:"name"`
+	DueOn     string                 `json:"due_on,omitempty"`
+	Notes     string                 `json:"notes,omitempty"`
+	BlockedBy []models.AsanaResource `json:"blocked_by,omitempty"`
+	Subtasks  []models.Task          `json:"subtasks,omitempty"`
+}
+
+// primeOmitted counts what --max-tokens dropped, so a reader knows the
+// context is partial.
+type primeOmitted struct {
+	MaxTokens    int `json:"max_tokens"`
+	Ready        int `json:"ready,omitempty"`
+	Blocked      int `json:"blocked,omitempty"`
+	Completed    int `json:"completed,omitempty"`
+	Notes        int `json:"notes,omitempty"`
+	NotesTrimmed int `json:"notes_trimmed,omitempty"`
+	Subtasks     int `json:"subtasks,omitempty"`
+	Comments     int `json:"comments,omitempty"`
+	SessionLogs  int `json:"session_logs,omitempty"`
 }
 
 func runPrime(_ *cobra.Command, _ []string) error {
@@ -53,9 +138,16 @@ func runPrime(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
-	project := primeProject
-	if project == "" && cfg.Project != "" {
-		project = cfg.Project
+	if primeFormat != "markdown" && primeFormat != "json" {
+		return errors.NewInvalidArgsError(fmt.Sprintf("invalid --format %q, must be markdown or json", primeFormat))
+	}
+
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	project, err := resolveProjectFlag(ctx, cfg, client, primeProject, false)
+	if err != nil {
+		return err
 	}
 	if project == "" {
 		return errors.NewGeneralError("no project specified via --project or context", nil)
@@ -66,113 +158,256 @@ func runPrime(_ *cobra.Command, _ []string) error {
 		fmt.Fprintf(os.Stderr, "- Project: %s\n", project)
 		fmt.Fprintf(os.Stderr, "- Limit: %d\n", primeLimit)
 		fmt.Fprintf(os.Stderr, "- Include completed: %v\n", primeIncludeCompleted)
+		fmt.Fprintf(os.Stderr, "- Format: %s\n", primeFormat)
+		fmt.Fprintf(os.Stderr, "- Max tokens: %d\n", primeMaxTokens)
 		return nil
 	}
 
-	client := newClient(cfg)
-	ctx := context.Background()
-
-	var output strings.Builder
-	output.WriteString("# Asana Context\n\n")
-
-	if err := writeActiveSession(&output, client, ctx); err != nil {
+	out, err := buildPrime(ctx, client, primeOptions{
+		Project:          project,
+		Limit:            primeLimit,
+		IncludeCompleted: primeIncludeCompleted,
+		Format:           primeFormat,
+		MaxTokens:        primeMaxTokens,
+		UserName:         userNamer(cfg, client),
+		IdleTimeout:      sessionIdleTimeout(cfg),
+	})
+	if err != nil {
 		return err
 	}
 
-	readyTasks, blockedTasks, err := fetchAndCategorize(client, project, primeLimit)
+	fmt.Print(out)
+	return nil
+}
+
+// buildPrime renders the context dump shared by the prime command and the
+// MCP prime tool.
+func buildPrime(ctx context.Context, client api.Client, opts primeOptions) (string, error) {
+	p, err := collectPrime(ctx, client, opts)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	if err := writeReadyTasks(&output, client, ctx, readyTasks); err != nil {
-		return err
+	render := renderPrimeMarkdown
+	if opts.Format == "json" {
+		render = renderPrimeJSON
 	}
+	if opts.MaxTokens > 0 {
+		fitPrime(p, opts.MaxTokens, render)
+	}
+	return render(p), nil
+}
 
-	if err := writeBlockedTasks(&output, client, ctx, blockedTasks); err != nil {
-		return err
+// collectPrime gathers the session, task lists and subtasks concurrently.
+// Slots are filled by index, so output order does not depend on which
+// request finishes first; the first fatal error cancels the rest.
+func collectPrime(ctx context.Context, client api.Client, opts primeOptions) (*primeContext, error) {
+	p := &primeContext{Project: opts.Project}
+
+	err := parallel.Do(ctx,
+		func(ctx context.Context) error {
+			p.Session = collectActiveSession(ctx, client, opts)
+			return nil
+		},
+		func(ctx context.Context) error {
+			readyTasks, blockedTasks, err := fetchAndCategorize(ctx, client, opts.Project, opts.Limit)
+			if err != nil {
+				return err
+			}
+			tasks := append(append([]models.Task{}, readyTasks...), blockedTasks...)
+			details, err := collectPrimeTasks(ctx, client, tasks)
+			if err != nil {
+				return err
+			}
+			p.Ready, p.Blocked = details[:len(readyTasks)], details[len(readyTasks):]
+			return nil
+		},
+		func(ctx context.Context) error {
+			if !opts.IncludeCompleted {
+				return nil
+			}
+			var err error
+			p.Completed, err = fetchCompletedTasks(ctx, client, opts.Project, opts.Limit)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
+	return p, nil
+}
 
-	if primeIncludeCompleted {
-		completedTasks, err := fetchCompletedTasks(client, project, primeLimit)
+// collectPrimeTasks fetches each task's subtasks on a bounded pool. A task
+// whose subtasks cannot be read is listed without them.
+func collectPrimeTasks(ctx context.Context, client api.Client, tasks []models.Task) ([]primeTask, error) {
+	details := make([]primeTask, len(tasks))
+	err := parallel.Each(ctx, len(tasks), api.MaxConcurrentRequests, func(ctx context.Context, i int) error {
+		task := tasks[i]
+		pt := primeTask{
+			GID:   task.GID,
+			Name:  task.Name,
+			DueOn: task.DueOn,
+			Notes: truncate(strings.Join(strings.Fields(task.Notes), " "), primeNoteLen),
+		}
+		if task.Dependencies != nil {
+			for _, dep := range *task.Dependencies {
+				if !dep.Completed {
+					pt.BlockedBy = append(pt.BlockedBy, models.AsanaResource{GID: dep.GID, Name: dep.Name})
+				}
+			}
+		}
+
+		subtasks, err := client.ListSubtasks(ctx, task.GID, 50, "")
 		if err != nil {
-			return err
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+		} else {
+			pt.Subtasks = subtasks.Data
 		}
-		writeCompletedTasks(&output, completedTasks)
+		details[i] = pt
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	fmt.Print(output.String())
-	return nil
+	return details, nil
 }
 
-func writeActiveSession(output *strings.Builder, client api.Client, ctx context.Context) error {
-	dir, err := getSessionDir()
+// collectActiveSession describes the session in the working directory. It
+// returns nil when there is none or its task cannot be read.
+func collectActiveSession(ctx context.Context, client api.Client, opts primeOptions) *primeSession {
+	store, err := sessionStore()
 	if err != nil {
 		return nil
 	}
 
-	sess, err := session.Load(dir)
+	sess, err := store.Current()
 	if err != nil || sess == nil {
 		return nil
 	}
-
-	task, err := client.GetTask(ctx, sess.TaskGID)
-	if err != nil {
+	// Only for the report; the pause is saved by the next session command.
+	sess.PauseIfIdle(opts.IdleTimeout, time.Now())
+
+	var (
+		task    *models.Task
+		stories *models.ListResponse[models.Story]
+	)
+	_ = parallel.Do(ctx,
+		func(ctx context.Context) error {
+			task, _ = client.GetTask(ctx, sess.TaskGID)
+			return nil
+		},
+		func(ctx context.Context) error {
+			stories, _ = api.Paginate(ctx, api.PageOptions{}, api.StoryPages(client, sess.TaskGID))
+			return nil
+		},
+	)
+	if task == nil {
 		return nil
 	}
 
+	ps := &primeSession{
+		TaskGID:        sess.TaskGID,
+		TaskName:       task.Name,
+		StartedAt:      sess.StartedAt,
+		Duration:       sess.FormatDuration(),
+		Elapsed:        sess.FormatElapsed(),
+		Paused:         sess.Paused(),
+		StartBranch:    sess.StartBranch,
+		Logs:           sess.Logs,
+		RecentComments: []primeComment{},
+	}
+	if ps.Logs == nil {
+		ps.Logs = []session.LogEntry{}
+	}
+	if sess.StartBranch != "" {
+		ps.CurrentBranch = session.GetCurrentBranch()
+	}
+
+	if stories != nil {
+		cutoff := time.Now().Add(-24 * time.Hour)
+		for _, story := range stories.Data {
+			if story.Type != "comment" || story.Text == "" {
+				continue
+			}
+			createdAt, err := time.Parse(time.RFC3339, story.CreatedAt)
+			if err != nil || !createdAt.After(cutoff) {
+				continue
+			}
+			by := "Unknown"
+			if story.CreatedBy != nil {
+				nameAuthor(ctx, &story, opts.UserName)
+				by = story.CreatedBy.Name
+				if by == "" {
+					by = story.CreatedBy.GID
+				}
+			}
+			ps.RecentComments = append(ps.RecentComments, primeComment{CreatedAt: createdAt, By: by, Text: story.Text})
+		}
+	}
+	return ps
+}
+
+func renderPrimeJSON(p *primeContext) string {
+	var buf strings.Builder
+	_ = output.NewJSON(&buf).Print(p)
+	return buf.String()
+}
+
+func renderPrimeMarkdown(p *primeContext) string {
+	var output strings.Builder
+	output.WriteString("# Asana Context\n\n")
+	writeActiveSession(&output, p.Session)
+	writeReadyTasks(&output, p.Ready)
+	writeBlockedTasks(&output, p.Blocked)
+	writeCompletedTasks(&output, p.Completed)
+	writeOmitted(&output, p.Omitted)
+	return output.String()
+}
+
+func writeActiveSession(output *strings.Builder, s *primeSession) {
+	if s == nil {
+		return
+	}
+
 	output.WriteString("## Active Session\n")
-	fmt.Fprintf(output, "Task: %s (%s)\n", task.Name, sess.TaskGID)
-	fmt.Fprintf(output, "Started: %s ago\n", sess.FormatDuration())
+	fmt.Fprintf(output, "Task: %s (%s)\n", s.TaskName, s.TaskGID)
+	fmt.Fprintf(output, "Started: %s ago, %s active", s.Elapsed, s.Duration)
+	if s.Paused {
+		output.WriteString(" (paused)")
+	}
+	output.WriteString("\n")
 
-	if sess.StartBranch != "" {
-		currentBranch := session.GetCurrentBranch()
-		if currentBranch != "" && currentBranch != sess.StartBranch {
-			fmt.Fprintf(output, "Branch: %s → %s\n", sess.StartBranch, currentBranch)
+	if s.StartBranch != "" {
+		if s.CurrentBranch != "" && s.CurrentBranch != s.StartBranch {
+			fmt.Fprintf(output, "Branch: %s → %s\n", s.StartBranch, s.CurrentBranch)
 		} else {
-			fmt.Fprintf(output, "Branch: %s\n", sess.StartBranch)
+			fmt.Fprintf(output, "Branch: %s\n", s.StartBranch)
 		}
 	}
 
-	if len(sess.Logs) > 0 {
+	if len(s.Logs) > 0 {
 		output.WriteString("Progress logs:\n")
-		for _, log := range sess.Logs {
+		for _, log := range s.Logs {
 			fmt.Fprintf(output, "- [%s] %s\n", log.Timestamp.Local().Format("15:04"), log.Text)
 		}
 	}
 
-	stories, err := client.ListStories(ctx, sess.TaskGID, 100, "")
-	if err == nil && len(stories.Data) > 0 {
-		output.WriteString("\nRecent comments (last 24h):\n")
-		cutoff := time.Now().Add(-24 * time.Hour)
-		hasRecent := false
-		for _, story := range stories.Data {
-			if story.Type == "comment" && story.Text != "" {
-				createdAt, err := time.Parse(time.RFC3339, story.CreatedAt)
-				if err == nil && createdAt.After(cutoff) {
-					hasRecent = true
-					by := "Unknown"
-					if story.CreatedBy != nil {
-						by = story.CreatedBy.Name
-					}
-					fmt.Fprintf(output, "- [%s] %s: %s\n",
-						createdAt.Local().Format("15:04"),
-						by,
-						truncate(story.Text, 100))
-				}
-			}
-		}
-		if !hasRecent {
-			output.WriteString("- (none)\n")
-		}
+	output.WriteString("\nRecent comments (last 24h):\n")
+	for _, c := range s.RecentComments {
+		fmt.Fprintf(output, "- [%s] %s: %s\n", c.CreatedAt.Local().Format("15:04"), c.By, truncate(c.Text, 100))
+	}
+	if len(s.RecentComments) == 0 {
+		output.WriteString("- (none)\n")
 	}
 
 	output.WriteString("\n")
-	return nil
 }
 
-func writeReadyTasks(output *strings.Builder, client api.Client, ctx context.Context, tasks []models.Task) error {
+func writeReadyTasks(output *strings.Builder, tasks []primeTask) {
 	if len(tasks) == 0 {
-		return nil
+		return
 	}
 
 	output.WriteString("## Ready Tasks (unblocked)\n")
@@ -182,38 +417,21 @@ func writeReadyTasks(output *strings.Builder, client api.Client, ctx context.Con
 			dueStr = fmt.Sprintf(" - due %s", task.DueOn)
 		}
 		fmt.Fprintf(output, "- [ ] %s (%s)%s\n", task.Name, task.GID, dueStr)
-
-		subtasks, err := client.ListSubtasks(ctx, task.GID, 50, "")
-		if err == nil && len(subtasks.Data) > 0 {
-			for _, subtask := range subtasks.Data {
-				checkbox := "[ ]"
-				if subtask.Completed {
-					checkbox = "[x]"
-				}
-				fmt.Fprintf(output, "  - %s %s\n", checkbox, subtask.Name)
-			}
-		}
+		writeTaskDetail(output, task)
 	}
 	output.WriteString("\n")
-	return nil
 }
 
-func writeBlockedTasks(output *strings.Builder, client api.Client, ctx context.Context, tasks []models.Task) error {
+func writeBlockedTasks(output *strings.Builder, tasks []primeTask) {
 	if len(tasks) == 0 {
-		return nil
+		return
 	}
 
 	output.WriteString("## Blocked Tasks\n")
 	for _, task := range tasks {
-		if task.Dependencies == nil {
-			return fmt.Errorf("task %s missing dependency data", task.GID)
-		}
-
 		var blockers []string
-		for _, dep := range *task.Dependencies {
-			if !dep.Completed {
-				blockers = append(blockers, dep.Name)
-			}
+		for _, dep := range task.BlockedBy {
+			blockers = append(blockers, dep.Name)
 		}
 
 		blockStr := ""
@@ -221,24 +439,54 @@ func writeBlockedTasks(output *strings.Builder, client api.Client, ctx context.C
 			blockStr = fmt.Sprintf(" - blocked by: %s", strings.Join(blockers, ", "))
 		}
 		fmt.Fprintf(output, "- [ ] %s (%s)%s\n", task.Name, task.GID, blockStr)
+		writeTaskDetail(output, task)
+	}
+	output.WriteString("\n")
+}
 
-		subtasks, err := client.ListSubtasks(ctx, task.GID, 50, "")
-		if err == nil && len(subtasks.Data) > 0 {
-			for _, subtask := range subtasks.Data {
-				checkbox := "[ ]"
-				if subtask.Completed {
-					checkbox = "[x]"
-				}
-				fmt.Fprintf(output, "  - %s %s\n", checkbox, subtask.Name)
-			}
+func writeTaskDetail(output *strings.Builder, task primeTask) {
+	if task.Notes != "" {
+		fmt.Fprintf(output, "  > %s\n", task.Notes)
+	}
+	for _, subtask := range task.Subtasks {
+		checkbox := "[ ]"
+		if subtask.Completed {
+			checkbox = "[x]"
 		}
+		fmt.Fprintf(output, "  - %s %s\n", checkbox, subtask.Name)
 	}
-	output.WriteString("\n")
-	return nil
 }
 
-func fetchAndCategorize(client api.Client, project string, limit int) ([]models.Task, []models.Task, error) {
-	incompleteTasks, err := fetchIncompleteTasksWithDeps(client, project, "", limit)
+func writeOmitted(output *strings.Builder, o *primeOmitted) {
+	if o == nil {
+		return
+	}
+
+	var parts []string
+	for _, c := range []struct {
+		n    int
+		what string
+	}{
+		{o.Ready, "ready tasks"},
+		{o.Blocked, "blocked tasks"},
+		{o.Completed, "completed tasks"},
+		{o.Notes, "task notes"},
+		{o.Subtasks, "subtasks"},
+		{o.Comments, "comments"},
+		{o.SessionLogs, "session logs"},
+	} {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.n, c.what))
+		}
+	}
+	if o.NotesTrimmed > 0 {
+		parts = append(parts, fmt.Sprintf("%d task notes shortened", o.NotesTrimmed))
+	}
+	fmt.Fprintf(output, "_Trimmed to about %d tokens: %s omitted._\n", o.MaxTokens, strings.Join(parts, ", "))
+}
+
+func fetchAndCategorize(ctx context.Context, client api.Client, project string, limit int) ([]models.Task, []models.Task, error) {
+	incompleteTasks, err := fetchIncompleteTasks(ctx, client, project, "", 0, append([]string{"notes"}, dependencyTaskFields...))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -257,7 +505,7 @@ func fetchAndCategorize(client api.Client, project string, limit int) ([]models.
 		}
 	}
 
-	return ready, blocked, nil
+	return capTasks(ready, limit), capTasks(blocked, limit), nil
 }
 
 func truncate(s string, maxLen int) string {
@@ -267,15 +515,14 @@ func truncate(s string, maxLen int) string {
 	return s[:maxLen] + "..."
 }
 
-func fetchCompletedTasks(client api.Client, project string, limit int) ([]models.Task, error) {
+func fetchCompletedTasks(ctx context.Context, client api.Client, project string, limit int) ([]models.Task, error) {
 	completed := true
 	opts := api.TaskListOptions{
 		Project:   project,
 		Completed: &completed,
-		Limit:     limit,
 	}
 
-	result, err := client.ListTasks(context.Background(), opts)
+	result, err := api.Paginate(ctx, api.PageOptions{MaxItems: limit}, api.TaskPages(client, opts))
 	if err != nil {
 		return nil, err
 	}
@@ -298,3 +545,111 @@ func writeCompletedTasks(output *strings.Builder, tasks []models.Task) {
 	}
 	output.WriteString("\n")
 }
+
+// estimateTokens approximates a token count at four characters per token,
+// close enough for English text and JSON to budget an agent's context.
+func estimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+// primeShortNoteLen is what notes are cut to before they are dropped.
+const primeShortNoteLen = 60
+
+// primeTrims reduce a prime context one step at a time, lowest priority
+// content first. Each reports false once it has nothing left to remove.
+var primeTrims = []func(p *primeContext, o *primeOmitted) bool{
+	func(p *primeContext, o *primeOmitted) bool {
+		if len(p.Completed) == 0 {
+			return false
+		}
+		p.Completed = p.Completed[:len(p.Completed)-1]
+		o.Completed++
+		return true
+	},
+	func(p *primeContext, o *primeOmitted) bool {
+		return trimTasks(p, func(t *primeTask) bool {
+			if len(t.Notes) <= primeShortNoteLen+len("...") {
+				return false
+			}
+			t.Notes = truncate(t.Notes, primeShortNoteLen)
+			o.NotesTrimmed++
+			return true
+		})
+	},
+	func(p *primeContext, o *primeOmitted) bool {
+		return trimTasks(p, func(t *primeTask) bool {
+			if t.Notes == "" {
+				return false
+			}
+			t.Notes = ""
+			o.Notes++
+			return true
+		})
+	},
+	func(p *primeContext, o *primeOmitted) bool {
+		return trimTasks(p, func(t *primeTask) bool {
+			if len(t.Subtasks) == 0 {
+				return false
+			}
+			t.Subtasks = t.Subtasks[:len(t.Subtasks)-1]
+			o.Subtasks++
+			return true
+		})
+	},
+	func(p *primeContext, o *primeOmitted) bool {
+		if p.Session == nil || len(p.Session.RecentComments) == 0 {
+			return false
+		}
+		p.Session.RecentComments = p.Session.RecentComments[1:]
+		o.Comments++
+		return true
+	},
+	func(p *primeContext, o *primeOmitted) bool {
+		if len(p.Blocked) == 0 {
+			return false
+		}
+		p.Blocked = p.Blocked[:len(p.Blocked)-1]
+		o.Blocked++
+		return true
+	},
+	func(p *primeContext, o *primeOmitted) bool {
+		if p.Session == nil || len(p.Session.Logs) <= 1 {
+			return false
+		}
+		p.Session.Logs = p.Session.Logs[1:]
+		o.SessionLogs++
+		return true
+	},
+	func(p *primeContext, o *primeOmitted) bool {
+		if len(p.Ready) <= 1 {
+			return false
+		}
+		p.Ready = p.Ready[:len(p.Ready)-1]
+		o.Ready++
+		return true
+	},
+}
+
+// trimTasks applies trim to the last task it changes, blocked tasks before
+// ready ones, so the top of each list keeps its detail longest.
+func trimTasks(p *primeContext, trim func(t *primeTask) bool) bool {
+	for _, tasks := range [][]primeTask{p.Blocked, p.Ready} {
+		for i := len(tasks) - 1; i >= 0; i-- {
+			if trim(&tasks[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fitPrime trims p until render's output fits maxTokens or nothing more can
+// be dropped, recording what was left out in p.Omitted.
+func fitPrime(p *primeContext, maxTokens int, render func(*primeContext) string) {
+	o := &primeOmitted{MaxTokens: maxTokens}
+	for _, trim := range primeTrims {
+		for estimateTokens(render(p)) > maxTokens && trim(p, o) {
+			p.Omitted = o
+		}
+	}
+}
@@ -1,9 +1,16 @@
 package cli
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
 	"github.com/whoaa512/asana-cli/internal/models"
 )
 
@@ -21,7 +28,7 @@ func TestPrimeCommandRegistered(t *testing.T) {
 }
 
 func TestPrimeCommandFlags(t *testing.T) {
-	flags := []string{"project", "limit", "include-completed"}
+	flags := []string{"project", "limit", "include-completed", "format", "max-tokens"}
 	for _, name := range flags {
 		if primeCmd.Flags().Lookup(name) == nil {
 			t.Errorf("flag %q not registered", name)
@@ -102,10 +109,7 @@ func TestWriteCompletedTasks(t *testing.T) {
 func TestWriteReadyTasks(t *testing.T) {
 	t.Run("empty tasks", func(t *testing.T) {
 		var output strings.Builder
-		err := writeReadyTasks(&output, nil, nil, nil)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
+		writeReadyTasks(&output, nil)
 		if output.String() != "" {
 			t.Errorf("expected empty output for nil tasks, got %q", output.String())
 		}
@@ -115,12 +119,151 @@ func TestWriteReadyTasks(t *testing.T) {
 func TestWriteBlockedTasks(t *testing.T) {
 	t.Run("empty tasks", func(t *testing.T) {
 		var output strings.Builder
-		err := writeBlockedTasks(&output, nil, nil, nil)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
+		writeBlockedTasks(&output, nil)
 		if output.String() != "" {
 			t.Errorf("expected empty output for nil tasks, got %q", output.String())
 		}
 	})
 }
+
+func TestRenderPrimeMarkdown(t *testing.T) {
+	p := &primeContext{
+		Ready:   []primeTask{{GID: "1", Name: "Write docs", DueOn: "2026-03-01", Notes: "Cover the new flags", Subtasks: []models.Task{{Name: "Outline", Completed: true}}}},
+		Blocked: []primeTask{{GID: "2", Name: "Release", BlockedBy: []models.AsanaResource{{GID: "1", Name: "Write docs"}}}},
+	}
+	result := renderPrimeMarkdown(p)
+
+	for _, want := range []string{
+		"# Asana Context\n",
+		"- [ ] Write docs (1) - due 2026-03-01\n  > Cover the new flags\n  - [x] Outline\n",
+		"- [ ] Release (2) - blocked by: Write docs\n",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("output missing %q:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "Trimmed") {
+		t.Error("untrimmed output should not mention trimming")
+	}
+}
+
+func primeFixture() *primeContext {
+	p := &primeContext{
+		Session: &primeSession{TaskGID: "9", TaskName: "Current", RecentComments: []primeComment{{By: "Ana", Text: "old"}, {By: "Ana", Text: "new"}}},
+	}
+	for i := 0; i < 10; i++ {
+		p.Ready = append(p.Ready, primeTask{GID: "r", Name: "Ready task", Notes: strings.Repeat("note ", 40), Subtasks: []models.Task{{Name: "step"}}})
+		p.Blocked = append(p.Blocked, primeTask{GID: "b", Name: "Blocked task", Notes: strings.Repeat("note ", 40)})
+		p.Completed = append(p.Completed, models.Task{GID: "c", Name: "Done task"})
+	}
+	return p
+}
+
+func TestFitPrime(t *testing.T) {
+	t.Run("drops lowest priority first", func(t *testing.T) {
+		p := primeFixture()
+		full := estimateTokens(renderPrimeMarkdown(p))
+		completed := estimateTokens(renderPrimeMarkdown(&primeContext{Completed: p.Completed}))
+
+		fitPrime(p, full-completed/2, renderPrimeMarkdown)
+
+		if p.Omitted == nil || p.Omitted.Completed == 0 {
+			t.Fatalf("Omitted = %+v, want completed tasks dropped", p.Omitted)
+		}
+		if p.Omitted.Notes != 0 || p.Omitted.NotesTrimmed != 0 || len(p.Ready) != 10 || len(p.Blocked) != 10 {
+			t.Errorf("trimmed more than needed: %+v", p.Omitted)
+		}
+		if got := estimateTokens(renderPrimeMarkdown(p)); got > full-completed/2 {
+			t.Errorf("estimate %d still over budget", got)
+		}
+	})
+
+	t.Run("keeps session and top ready task", func(t *testing.T) {
+		p := primeFixture()
+		fitPrime(p, 1, renderPrimeJSON)
+
+		if p.Session == nil || len(p.Ready) != 1 || len(p.Blocked) != 0 || len(p.Completed) != 0 {
+			t.Fatalf("got session=%v ready=%d blocked=%d completed=%d", p.Session != nil, len(p.Ready), len(p.Blocked), len(p.Completed))
+		}
+		if p.Ready[0].Notes != "" || len(p.Ready[0].Subtasks) != 0 || len(p.Session.RecentComments) != 0 {
+			t.Errorf("detail left on the remaining task: %+v", p.Ready[0])
+		}
+		want := primeOmitted{MaxTokens: 1, Ready: 9, Blocked: 10, Completed: 10, Notes: 20, NotesTrimmed: 20, Subtasks: 10, Comments: 2}
+		if *p.Omitted != want {
+			t.Errorf("Omitted = %+v, want %+v", *p.Omitted, want)
+		}
+		if !strings.Contains(renderPrimeJSON(p), `"omitted": {`) {
+			t.Error("JSON output should report what was omitted")
+		}
+	})
+
+	t.Run("fits without trimming", func(t *testing.T) {
+		p := primeFixture()
+		fitPrime(p, 1<<20, renderPrimeMarkdown)
+		if p.Omitted != nil {
+			t.Errorf("Omitted = %+v, want nil", p.Omitted)
+		}
+	})
+}
+
+func TestCollectPrimeTasks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gid := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/tasks/"), "/subtasks")
+		if gid == "broken" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors": [{"message": "gone"}]}`))
+			return
+		}
+		n, _ := strconv.Atoi(gid)
+		// Later tasks answer first, so completion order differs from input order.
+		time.Sleep(time.Duration(20-n) * time.Millisecond)
+		_, _ = w.Write([]byte(`{"data": [{"gid": "s` + gid + `", "name": "sub ` + gid + `"}]}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := api.NewHTTPClient(cfg, api.WithBaseURL(server.URL))
+
+	var tasks []models.Task
+	for i := 0; i < 20; i++ {
+		tasks = append(tasks, models.Task{GID: strconv.Itoa(i), Name: "Task", Notes: "line one\n\nline   two"})
+	}
+	tasks = append(tasks, models.Task{GID: "broken", Dependencies: &[]models.Task{{GID: "0", Name: "Task"}, {GID: "x", Completed: true}}})
+
+	details, err := collectPrimeTasks(context.Background(), client, tasks)
+	if err != nil {
+		t.Fatalf("collectPrimeTasks() error = %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		if details[i].GID != strconv.Itoa(i) || len(details[i].Subtasks) != 1 || details[i].Subtasks[0].Name != "sub "+strconv.Itoa(i) {
+			t.Fatalf("details[%d] = %+v, want subtasks matched to input order", i, details[i])
+		}
+	}
+	if details[0].Notes != "line one line two" {
+		t.Errorf("notes = %q, want whitespace collapsed", details[0].Notes)
+	}
+
+	broken := details[20]
+	if broken.Subtasks != nil {
+		t.Errorf("subtasks = %v, want none when they cannot be read", broken.Subtasks)
+	}
+	if len(broken.BlockedBy) != 1 || broken.BlockedBy[0].GID != "0" {
+		t.Errorf("blocked_by = %v, want only the incomplete dependency", broken.BlockedBy)
+	}
+}
+
+func TestCollectPrimeTasks_Cancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := api.NewHTTPClient(cfg, api.WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := collectPrimeTasks(ctx, client, []models.Task{{GID: "1"}}); err == nil {
+		t.Error("expected an error when the context is cancelled")
+	}
+}
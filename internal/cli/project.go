@@ -48,6 +48,7 @@ var (
 	projectListArchived bool
 	projectListLimit    int
 	projectListOffset   string
+	projectListPages    pageFlags
 
 	projectCreateName  string
 	projectCreateNotes string
@@ -64,11 +65,12 @@ func init() {
 	projectListCmd.Flags().BoolVar(&projectListArchived, "archived", false, "Include archived projects")
 	projectListCmd.Flags().IntVar(&projectListLimit, "limit", 50, "Max results to return")
 	projectListCmd.Flags().StringVar(&projectListOffset, "offset", "", "Pagination offset")
+	addPageFlags(projectListCmd, &projectListPages)
 
 	projectCreateCmd.Flags().StringVar(&projectCreateName, "name", "", "Project name (required)")
 	projectCreateCmd.Flags().StringVar(&projectCreateNotes, "notes", "", "Project description")
 	projectCreateCmd.Flags().StringVar(&projectCreateColor, "color", "", "Project color")
-	projectCreateCmd.Flags().StringVar(&projectCreateTeam, "team", "", "Team GID (required for org workspaces)")
+	projectCreateCmd.Flags().StringVar(&projectCreateTeam, "team", "", "Team GID or name (required for org workspaces)")
 	_ = projectCreateCmd.MarkFlagRequired("name")
 }
 
@@ -88,18 +90,10 @@ func runProjectList(_ *cobra.Command, _ []string) error {
 	opts := api.ProjectListOptions{
 		Workspace: cfg.Workspace,
 		Archived:  projectListArchived,
-		Limit:     projectListLimit,
-		Offset:    projectListOffset,
 	}
 
 	client := newClient(cfg)
-	result, err := client.ListProjects(context.Background(), opts)
-	if err != nil {
-		return err
-	}
-
-	out := output.NewJSON(os.Stdout)
-	return out.Print(result)
+	return printList(context.Background(), &projectListPages, projectListLimit, projectListOffset, api.ProjectPages(client, opts))
 }
 
 func runProjectGet(_ *cobra.Command, args []string) error {
@@ -139,7 +133,13 @@ func runProjectCreate(_ *cobra.Command, _ []string) error {
 		Notes: projectCreateNotes,
 		Color: projectCreateColor,
 	}
-	team := projectCreateTeam
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	team, err := resolveTeamGID(ctx, cfg, client, projectCreateTeam, false)
+	if err != nil {
+		return err
+	}
 	if team == "" {
 		team = cfg.Team
 	}
@@ -154,8 +154,7 @@ func runProjectCreate(_ *cobra.Command, _ []string) error {
 		return out.Print(map[string]any{"dry_run": true, "request": req})
 	}
 
-	client := newClient(cfg)
-	project, err := client.CreateProject(context.Background(), req)
+	project, err := client.CreateProject(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -4,33 +4,62 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
 	"github.com/whoaa512/asana-cli/internal/errors"
 	"github.com/whoaa512/asana-cli/internal/models"
 	"github.com/whoaa512/asana-cli/internal/output"
+	"github.com/whoaa512/asana-cli/internal/parallel"
+	"github.com/whoaa512/asana-cli/internal/ranking"
 )
 
 var readyCmd = &cobra.Command{
 	Use:   "ready",
 	Short: "List tasks with no incomplete dependencies",
-	Long:  "List tasks that are ready to work on (no blocking dependencies). Filters by project and assignee.",
-	RunE:  runReady,
+	Long: `List tasks that are ready to work on (no blocking dependencies). Filters by
+project and assignee.
+
+With --sort score, tasks are ranked by a weighted sum of:
+
+  due       1 when due today or overdue, falling off over the following weeks
+  unblocks  how many incomplete tasks depend on it
+  position  how early its section comes in the project
+  tags      the sum of the tag weights configured in .asana.json
+  assignee  1 when assigned to you, 0.5 when unassigned
+
+and each task carries its score and score_breakdown. Weights default to
+due 3, unblocks 2 and 1 for the rest; override them in .asana.json:
+
+  "scoring": {"weights": {"due": 5}, "tags": {"urgent": 2, "someday": -1}}`,
+	Example: `  # Best task to pick up next, with the reasoning
+  asana ready --sort score --limit 5`,
+	RunE: runReady,
 }
 
 var (
 	readyProject  string
 	readyAssignee string
 	readyLimit    int
+	readySort     string
+	readyPages    pageFlags
+)
+
+const (
+	readySortAPI   = "api"
+	readySortScore = "score"
 )
 
 func init() {
 	rootCmd.AddCommand(readyCmd)
-	readyCmd.Flags().StringVar(&readyProject, "project", "", "Filter by project GID")
-	readyCmd.Flags().StringVar(&readyAssignee, "assignee", "", "Filter by assignee GID or 'me'")
+	readyCmd.Flags().StringVar(&readyProject, "project", "", "Filter by project GID or name")
+	readyCmd.Flags().StringVar(&readyAssignee, "assignee", "", "Filter by assignee GID, email, name or 'me'")
 	readyCmd.Flags().IntVar(&readyLimit, "limit", 20, "Max results to return")
+	readyCmd.Flags().StringVar(&readySort, "sort", readySortAPI, "Order: api (project order) or score")
+	addPageFlags(readyCmd, &readyPages)
 }
 
 func runReady(_ *cobra.Command, _ []string) error {
@@ -42,52 +71,202 @@ func runReady(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
-	project := readyProject
-	if project == "" && cfg.Project != "" {
-		project = cfg.Project
+	if readySort != readySortAPI && readySort != readySortScore {
+		return errors.NewGeneralError(fmt.Sprintf("invalid --sort %q (valid: api, score)", readySort), nil)
+	}
+
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	project, err := resolveProjectFlag(ctx, cfg, client, readyProject, false)
+	if err != nil {
+		return err
 	}
 	if project == "" {
 		return errors.NewGeneralError("no project specified via --project or context", nil)
 	}
+	assignee, err := resolveUserGID(ctx, cfg, client, readyAssignee, false)
+	if err != nil {
+		return err
+	}
 
 	if cfg.DryRun {
 		out := output.NewJSON(os.Stdout)
 		return out.Print(map[string]any{
 			"dry_run":  true,
 			"project":  project,
-			"assignee": readyAssignee,
+			"assignee": assignee,
 			"limit":    readyLimit,
+			"sort":     readySort,
+			"all":      readyPages.all,
+			"max":      readyPages.max,
 			"action":   "ready",
 		})
 	}
 
-	client := newClient(cfg)
+	out := newOutput()
+	if readySort == readySortScore {
+		// Scoring compares every ready task, so the whole project is read.
+		tasks, err := fetchIncompleteTasks(ctx, client, project, assignee, readyPages.max, scoreTaskFields)
+		if err != nil {
+			return err
+		}
+		readyTasks, err := filterReadyTasks(tasks)
+		if err != nil {
+			return err
+		}
+		ranked, err := rankReadyTasks(ctx, cfg, client, project, readyTasks)
+		if err != nil {
+			return err
+		}
+		if !readyPages.all && readyLimit > 0 && len(ranked) > readyLimit {
+			ranked = ranked[:readyLimit]
+		}
+		return out.Print(ranked)
+	}
 
-	incompleteTasks, err := fetchIncompleteTasksWithDeps(client, project, readyAssignee, readyLimit)
+	limit := readyLimit
+	if readyPages.all {
+		limit = 0
+	}
+	readyTasks, err := fetchMatchingTasks(ctx, client, project, assignee, readyPages.max, limit, filterReadyTasks)
 	if err != nil {
 		return err
 	}
+	if !readyPages.all {
+		readyTasks = capTasks(readyTasks, readyLimit)
+	}
+	return out.PrintTasks(readyTasks)
+}
 
-	readyTasks, err := filterReadyTasks(incompleteTasks)
+type scoredTask struct {
+	models.Task
+	Score          float64                   `json:"score"`
+	ScoreBreakdown map[string]ranking.Factor `json:"score_breakdown"`
+}
+
+// rankReadyTasks scores ready tasks, given in project order and fetched with
+// scoreTaskFields, with the weights configured in cfg and returns them best
+// first. Every task is scored, so callers should apply limits afterwards.
+func rankReadyTasks(ctx context.Context, cfg *config.Config, client api.Client, project string, ready []models.Task) ([]scoredTask, error) {
+	ranked := []scoredTask{}
+	if len(ready) == 0 {
+		return ranked, nil
+	}
+
+	model, err := ranking.NewModel(cfg.Scoring, "", time.Now())
 	if err != nil {
-		return err
+		return nil, errors.NewGeneralError(err.Error(), nil)
 	}
 
-	out := newOutput()
-	return out.PrintTasks(readyTasks)
+	var dependents map[string]int
+	var sections []models.Section
+	err = parallel.Do(ctx,
+		func(ctx context.Context) error {
+			if model.Weights[ranking.FactorAssignee] == 0 {
+				return nil
+			}
+			user, err := client.GetMe(ctx)
+			if err != nil {
+				return err
+			}
+			model.Me = user.GID
+			return nil
+		},
+		func(ctx context.Context) (err error) {
+			dependents, err = countDependents(ctx, client, ready)
+			return err
+		},
+		func(ctx context.Context) error {
+			if model.Weights[ranking.FactorPosition] == 0 {
+				return nil
+			}
+			result, err := api.Paginate(ctx, api.PageOptions{}, api.SectionPages(client, api.SectionListOptions{Project: project}))
+			if err != nil {
+				return err
+			}
+			sections = result.Data
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	order := make(map[string]int, len(sections))
+	for i, s := range sections {
+		order[s.GID] = i
+	}
+	candidates := make([]ranking.Candidate, len(ready))
+	for i, task := range ready {
+		// Tasks outside any known section rank after every section.
+		position := len(sections)
+		if s := task.SectionIn(project); s != nil {
+			if idx, ok := order[s.GID]; ok {
+				position = idx
+			}
+		}
+		candidates[i] = ranking.Candidate{Task: task, Dependents: dependents[task.GID], Position: position, Total: len(sections)}
+	}
+	for _, r := range model.Rank(candidates) {
+		ranked = append(ranked, scoredTask{Task: r.Task, Score: r.Score, ScoreBreakdown: r.Breakdown})
+	}
+	return ranked, nil
 }
 
-func fetchIncompleteTasksWithDeps(client api.Client, project, assignee string, limit int) ([]models.Task, error) {
-	completed := false
-	opts := api.TaskListOptions{
-		Project:   project,
-		Assignee:  assignee,
-		Completed: &completed,
-		Limit:     limit,
-		OptFields: []string{"name", "completed", "dependencies", "dependencies.completed"},
+// countDependents returns, per task, how many incomplete tasks depend on it.
+func countDependents(ctx context.Context, client api.Client, tasks []models.Task) (map[string]int, error) {
+	actions := make([]api.BatchAction, len(tasks))
+	for i, task := range tasks {
+		actions[i] = api.ListDependentsAction(task.GID, []string{"completed"})
+	}
+	results, err := client.Batch(ctx, actions)
+	if err != nil {
+		return nil, err
 	}
 
-	result, err := client.ListTasks(context.Background(), opts)
+	counts := make(map[string]int, len(tasks))
+	for i, r := range results {
+		var dependents []models.Task
+		if err := r.Decode(&dependents); err != nil {
+			return nil, err
+		}
+		for _, d := range dependents {
+			if !d.Completed {
+				counts[tasks[i].GID]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+var dependencyTaskFields = []string{"name", "completed", "due_on", "assignee", "tags.name", "dependencies", "dependencies.name", "dependencies.completed"}
+
+// scoreTaskFields adds the sections tasks sit in, which rankReadyTasks needs.
+var scoreTaskFields = append([]string{"memberships.project", "memberships.section"}, dependencyTaskFields...)
+
+// fetchMatchingTasks pages through the incomplete tasks in the project, with
+// their dependencies, and returns those filter keeps. It stops once limit
+// tasks are kept when limit is positive, or after maxItems tasks when
+// maxItems is.
+func fetchMatchingTasks(ctx context.Context, client api.Client, project, assignee string, maxItems, limit int, filter func([]models.Task) ([]models.Task, error)) ([]models.Task, error) {
+	var matched []models.Task
+	_, err := api.EachPage(ctx, api.PageOptions{MaxItems: maxItems}, incompleteTaskPages(client, project, assignee, dependencyTaskFields), func(page []models.Task) (bool, error) {
+		kept, err := filter(page)
+		if err != nil {
+			return false, err
+		}
+		matched = append(matched, kept...)
+		return limit <= 0 || len(matched) < limit, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matched, nil
+}
+
+func fetchIncompleteTasks(ctx context.Context, client api.Client, project, assignee string, maxItems int, fields []string) ([]models.Task, error) {
+	result, err := api.Paginate(ctx, api.PageOptions{MaxItems: maxItems}, incompleteTaskPages(client, project, assignee, fields))
 	if err != nil {
 		return nil, err
 	}
@@ -95,6 +274,16 @@ func fetchIncompleteTasksWithDeps(client api.Client, project, assignee string, l
 	return result.Data, nil
 }
 
+func incompleteTaskPages(client api.Client, project, assignee string, fields []string) api.PageFunc[models.Task] {
+	completed := false
+	return api.TaskPages(client, api.TaskListOptions{
+		Project:   project,
+		Assignee:  assignee,
+		Completed: &completed,
+		OptFields: fields,
+	})
+}
+
 func filterReadyTasks(tasks []models.Task) ([]models.Task, error) {
 	var ready []models.Task
 	for _, task := range tasks {
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/models"
+	"github.com/whoaa512/asana-cli/internal/ranking"
+)
+
+func TestRankReadyTasks(t *testing.T) {
+	client := dependencyServer(t, map[string]string{
+		"1/dependents": `[]`,
+		"2/dependents": `[{"gid": "5", "completed": false}, {"gid": "6", "completed": false}, {"gid": "7", "completed": true}]`,
+	})
+	cfg := &config.Config{Scoring: &config.Scoring{Weights: map[string]float64{ranking.FactorAssignee: 0, ranking.FactorPosition: 0}}}
+
+	ranked, err := rankReadyTasks(context.Background(), cfg, client, "P", []models.Task{
+		{GID: "1", Name: "First in project"},
+		{GID: "2", Name: "Unblocks two"},
+	})
+	if err != nil {
+		t.Fatalf("rankReadyTasks() error = %v", err)
+	}
+
+	if len(ranked) != 2 || ranked[0].GID != "2" {
+		t.Fatalf("ranked = %+v, want task 2 first", ranked)
+	}
+	if got := ranked[0].ScoreBreakdown[ranking.FactorUnblocks].Value; got != 0.667 {
+		t.Errorf("unblocks value = %v, want 0.667 for two incomplete dependents", got)
+	}
+	if ranked[0].Score <= ranked[1].Score {
+		t.Errorf("scores = %v, %v, want descending", ranked[0].Score, ranked[1].Score)
+	}
+}
+
+func TestRankReadyTasks_Empty(t *testing.T) {
+	ranked, err := rankReadyTasks(context.Background(), &config.Config{}, nil, "P", nil)
+	if err != nil || ranked == nil || len(ranked) != 0 {
+		t.Errorf("rankReadyTasks(nil) = %v, %v, want empty list", ranked, err)
+	}
+}
+
+func TestRankReadyTasksBySection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/P/sections":
+			_, _ = w.Write([]byte(`{"data": [{"gid": "s1", "name": "Now"}, {"gid": "s2", "name": "Later"}]}`))
+		case "/batch":
+			_, _ = w.Write([]byte(`{"data": [
+				{"status_code": 200, "body": {"data": []}},
+				{"status_code": 200, "body": {"data": []}},
+				{"status_code": 200, "body": {"data": []}}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := api.NewHTTPClient(cfg, api.WithBaseURL(server.URL))
+	cfg.Scoring = &config.Scoring{Weights: map[string]float64{ranking.FactorAssignee: 0}}
+
+	in := func(section string) []models.Membership {
+		return []models.Membership{{Project: &models.AsanaResource{GID: "P"}, Section: &models.AsanaResource{GID: section}}}
+	}
+	ranked, err := rankReadyTasks(context.Background(), cfg, client, "P", []models.Task{
+		{GID: "1", Memberships: in("s2")},
+		{GID: "2", Memberships: in("s2")},
+		{GID: "3", Memberships: in("s1")},
+	})
+	if err != nil {
+		t.Fatalf("rankReadyTasks() error = %v", err)
+	}
+
+	var order []string
+	for _, r := range ranked {
+		order = append(order, r.GID)
+	}
+	if !reflect.DeepEqual(order, []string{"3", "1", "2"}) {
+		t.Errorf("order = %v, want the first section first, then project order", order)
+	}
+	if got := ranked[0].ScoreBreakdown[ranking.FactorPosition].Value; got != 1 {
+		t.Errorf("position value = %v, want 1 for the first section", got)
+	}
+	if got := ranked[1].ScoreBreakdown[ranking.FactorPosition].Value; got != 0.5 {
+		t.Errorf("position value = %v, want 0.5 for the second of two sections", got)
+	}
+}
+
+func TestFetchMatchingTasksStopsAtLimit(t *testing.T) {
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		if pages == 1 {
+			_, _ = w.Write([]byte(`{"data": [
+				{"gid": "1", "dependencies": [{"gid": "9", "completed": false}]},
+				{"gid": "2", "dependencies": []}
+			], "next_page": {"offset": "p2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": [{"gid": "3", "dependencies": []}], "next_page": {"offset": "p3"}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second}
+	client := api.NewHTTPClient(cfg, api.WithBaseURL(server.URL))
+
+	ready, err := fetchMatchingTasks(context.Background(), client, "P", "", 0, 2, filterReadyTasks)
+	if err != nil {
+		t.Fatalf("fetchMatchingTasks() error = %v", err)
+	}
+	if len(ready) != 2 || ready[0].GID != "2" || ready[1].GID != "3" {
+		t.Errorf("ready = %v, want tasks 2 and 3", ready)
+	}
+	if pages != 2 {
+		t.Errorf("fetched %d pages, want 2", pages)
+	}
+}
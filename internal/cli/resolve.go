@@ -16,6 +16,8 @@ import (
 
 var gidRegex = regexp.MustCompile(`^\d+$`)
 
+const maxRecentTasks = 200
+
 type taskMatch struct {
 	task  models.Task
 	score int
@@ -59,7 +61,6 @@ func resolveTaskGID(ctx context.Context, cfg *config.Config, client api.Client,
 func fetchRecentTasks(ctx context.Context, cfg *config.Config, client api.Client) ([]models.Task, error) {
 	opts := api.TaskListOptions{
 		Assignee: "me",
-		Limit:    200,
 	}
 
 	if cfg.Project != "" {
@@ -70,7 +71,7 @@ func fetchRecentTasks(ctx context.Context, cfg *config.Config, client api.Client
 		return nil, errors.NewGeneralError("no project or workspace configured", nil)
 	}
 
-	result, err := client.ListTasks(ctx, opts)
+	result, err := api.Paginate(ctx, api.PageOptions{MaxItems: maxRecentTasks}, api.TaskPages(client, opts))
 	if err != nil {
 		return nil, err
 	}
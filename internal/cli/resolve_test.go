@@ -1,8 +1,18 @@
 package cli
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/errors"
 	"github.com/whoaa512/asana-cli/internal/models"
 )
 
@@ -100,3 +110,95 @@ func TestGIDRegex(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchResources(t *testing.T) {
+	items := []namedResource{
+		{GID: "1", Name: "In Review"},
+		{GID: "2", Name: "In Progress"},
+		{GID: "3", Name: "Review Backlog"},
+		{GID: "4", Name: "Ada Lovelace", Detail: "ada@example.com"},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"in review", []string{"1"}},
+		{"ADA@example.com", []string{"4"}},
+		{"review", []string{"1", "3"}},
+		{"inprog", []string{"2"}},
+		{"nothing", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var got []string
+			for _, m := range matchResources(items, tt.query) {
+				got = append(got, m.GID)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matchResources(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveSectionGID(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/projects/100/sections" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"data": [
+			{"gid": "1", "name": "In Review"},
+			{"gid": "2", "name": "Review Backlog"},
+			{"gid": "3", "name": "Done"}
+		]}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second, Sections: map[string]string{"done": "3"}}
+	client := api.NewHTTPClient(cfg, api.WithBaseURL(server.URL))
+	ctx := context.Background()
+	projects := func(context.Context) ([]models.AsanaResource, error) {
+		return []models.AsanaResource{{GID: "100"}}, nil
+	}
+
+	for input, want := range map[string]string{"done": "3", "42": "42", "In Review": "1", "backlog": "2"} {
+		got, err := resolveSectionGID(ctx, cfg, client, projects, input, false)
+		if err != nil {
+			t.Fatalf("resolveSectionGID(%q) error = %v", input, err)
+		}
+		if got != want {
+			t.Errorf("resolveSectionGID(%q) = %q, want %q", input, got, want)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("sections fetched %d times, want 2 (aliases and GIDs need no lookup)", requests)
+	}
+
+	_, err := resolveSectionGID(ctx, cfg, client, projects, "review", false)
+	cliErr := errors.AsCLIError(err)
+	if cliErr == nil || !strings.Contains(cliErr.Message, "multiple sections match") {
+		t.Fatalf("resolveSectionGID(ambiguous) error = %v, want multiple match error", err)
+	}
+	details, _ := cliErr.Details.(map[string]any)
+	if matches, _ := details["matches"].([]namedResource); len(matches) != 2 {
+		t.Errorf("error details = %v, want both matches", cliErr.Details)
+	}
+}
+
+func TestResolveUserGIDPassesThroughIdentifiers(t *testing.T) {
+	cfg := &config.Config{}
+	for _, input := range []string{"", "me", "123", "ada@example.com"} {
+		got, err := resolveUserGID(context.Background(), cfg, nil, input, false)
+		if err != nil {
+			t.Fatalf("resolveUserGID(%q) error = %v", input, err)
+		}
+		if got != input {
+			t.Errorf("resolveUserGID(%q) = %q, want it unchanged", input, got)
+		}
+	}
+}
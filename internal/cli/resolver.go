@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+// namedResource is a user, project, section, tag or team that can be looked
+// up by name. Detail disambiguates lookalikes in the picker: a user's email,
+// or the project a section belongs to.
+type namedResource struct {
+	GID    string `json:"gid"`
+	Name   string `json:"name"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func (r namedResource) GetName() string {
+	if r.Detail == "" {
+		return r.Name
+	}
+	return r.Name + " (" + r.Detail + ")"
+}
+
+func (r namedResource) GetGID() string { return r.GID }
+
+type listResources func(ctx context.Context) ([]namedResource, error)
+
+// resolveResource returns the GID of the kind of resource nameOrGID refers
+// to. GIDs and empty strings are returned as-is. Otherwise exact name or
+// detail matches win over fuzzy ones, and several matches go to the picker
+// when allowPick is set. Lists come from cached API responses, so repeat
+// lookups are cheap.
+func resolveResource(ctx context.Context, kind, nameOrGID string, allowPick bool, list listResources) (string, error) {
+	if nameOrGID == "" || gidRegex.MatchString(nameOrGID) {
+		return nameOrGID, nil
+	}
+
+	items, err := list(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	matches := matchResources(items, nameOrGID)
+	switch {
+	case len(matches) == 0:
+		return "", errors.NewGeneralError(fmt.Sprintf("no %ss found matching '%s'", kind, nameOrGID), nil)
+	case len(matches) == 1:
+		return matches[0].GID, nil
+	case !allowPick:
+		err := errors.NewGeneralError(fmt.Sprintf("multiple %ss match '%s', use a GID or --pick for interactive selection", kind, nameOrGID), nil)
+		err.Details = map[string]any{"matches": matches}
+		return "", err
+	}
+
+	selected, err := pick(matches, "Select a "+kind)
+	if err != nil {
+		return "", err
+	}
+	return selected.GID, nil
+}
+
+func matchResources(items []namedResource, query string) []namedResource {
+	var exact []namedResource
+	for _, item := range items {
+		if strings.EqualFold(item.Name, query) || (item.Detail != "" && strings.EqualFold(item.Detail, query)) {
+			exact = append(exact, item)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+
+	var matches []namedResource
+	for _, result := range fuzzy.Find(query, names) {
+		matches = append(matches, items[result.Index])
+	}
+	return matches
+}
+
+// resolveUserGID passes through "me", GIDs and emails, which Asana accepts
+// anywhere a user is expected, and looks names up in the member directory,
+// refreshing it once if the name isn't there.
+func resolveUserGID(ctx context.Context, cfg *config.Config, client api.Client, nameOrGID string, allowPick bool) (string, error) {
+	if nameOrGID == "me" || strings.Contains(nameOrGID, "@") {
+		return nameOrGID, nil
+	}
+	return resolveResource(ctx, "user", nameOrGID, allowPick, func(ctx context.Context) ([]namedResource, error) {
+		if _, err := requireWorkspace(cfg, "user", nameOrGID); err != nil {
+			return nil, err
+		}
+		dir, err := userDirectory(ctx, cfg, client, false)
+		if err != nil {
+			return nil, err
+		}
+		items := userResources(dir.Users)
+		if len(matchResources(items, nameOrGID)) == 0 && time.Since(dir.FetchedAt) > time.Minute {
+			if dir, err = userDirectory(ctx, cfg, client, true); err != nil {
+				return nil, err
+			}
+			items = userResources(dir.Users)
+		}
+		return items, nil
+	})
+}
+
+func userResources(users []models.User) []namedResource {
+	items := make([]namedResource, len(users))
+	for i, u := range users {
+		items[i] = namedResource{GID: u.GID, Name: u.Name, Detail: u.Email}
+	}
+	return items
+}
+
+func resolveProjectGID(ctx context.Context, cfg *config.Config, client api.Client, nameOrGID string, allowPick bool) (string, error) {
+	return resolveResource(ctx, "project", nameOrGID, allowPick, func(ctx context.Context) ([]namedResource, error) {
+		workspace, err := requireWorkspace(cfg, "project", nameOrGID)
+		if err != nil {
+			return nil, err
+		}
+		result, err := api.Paginate(ctx, api.PageOptions{}, api.ProjectPages(client, api.ProjectListOptions{Workspace: workspace}))
+		if err != nil {
+			return nil, err
+		}
+		items := make([]namedResource, len(result.Data))
+		for i, p := range result.Data {
+			items[i] = namedResource{GID: p.GID, Name: p.Name}
+		}
+		return items, nil
+	})
+}
+
+// resolveProjectFlag resolves a --project value, falling back to the
+// project from context, which is always a GID.
+func resolveProjectFlag(ctx context.Context, cfg *config.Config, client api.Client, flag string, allowPick bool) (string, error) {
+	if flag == "" {
+		return cfg.Project, nil
+	}
+	return resolveProjectGID(ctx, cfg, client, flag, allowPick)
+}
+
+// resolveSectionGID looks a section up in the projects returned by
+// projects, which is only called for names. Section aliases from .asana.json
+// (in_progress, done, ...) resolve without an API call.
+func resolveSectionGID(ctx context.Context, cfg *config.Config, client api.Client, projects func(ctx context.Context) ([]models.AsanaResource, error), nameOrGID string, allowPick bool) (string, error) {
+	if gid := cfg.Sections[nameOrGID]; gid != "" {
+		return gid, nil
+	}
+	return resolveResource(ctx, "section", nameOrGID, allowPick, func(ctx context.Context) ([]namedResource, error) {
+		projects, err := projects(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(projects) == 0 {
+			return nil, errors.NewGeneralError(fmt.Sprintf("no project to look up section '%s' in, use a GID or set a project", nameOrGID), nil)
+		}
+		var items []namedResource
+		for _, p := range projects {
+			result, err := api.Paginate(ctx, api.PageOptions{}, api.SectionPages(client, api.SectionListOptions{Project: p.GID}))
+			if err != nil {
+				return nil, err
+			}
+			for _, s := range result.Data {
+				r := namedResource{GID: s.GID, Name: s.Name}
+				if len(projects) > 1 {
+					r.Detail = p.Name
+				}
+				items = append(items, r)
+			}
+		}
+		return items, nil
+	})
+}
+
+func resolveTagGID(ctx context.Context, cfg *config.Config, client api.Client, nameOrGID string, allowPick bool) (string, error) {
+	return resolveResource(ctx, "tag", nameOrGID, allowPick, func(ctx context.Context) ([]namedResource, error) {
+		workspace, err := requireWorkspace(cfg, "tag", nameOrGID)
+		if err != nil {
+			return nil, err
+		}
+		result, err := api.Paginate(ctx, api.PageOptions{}, api.TagPages(client, api.TagListOptions{Workspace: workspace}))
+		if err != nil {
+			return nil, err
+		}
+		items := make([]namedResource, len(result.Data))
+		for i, t := range result.Data {
+			items[i] = namedResource{GID: t.GID, Name: t.Name}
+		}
+		return items, nil
+	})
+}
+
+func resolveTeamGID(ctx context.Context, cfg *config.Config, client api.Client, nameOrGID string, allowPick bool) (string, error) {
+	return resolveResource(ctx, "team", nameOrGID, allowPick, func(ctx context.Context) ([]namedResource, error) {
+		workspace, err := requireWorkspace(cfg, "team", nameOrGID)
+		if err != nil {
+			return nil, err
+		}
+		result, err := api.Paginate(ctx, api.PageOptions{}, api.UserTeamPages(client, api.UserTeamListOptions{Organization: workspace}))
+		if err != nil {
+			return nil, err
+		}
+		items := make([]namedResource, len(result.Data))
+		for i, t := range result.Data {
+			items[i] = namedResource{GID: t.GID, Name: t.Name}
+		}
+		return items, nil
+	})
+}
+
+func requireWorkspace(cfg *config.Config, kind, name string) (string, error) {
+	if cfg.Workspace == "" {
+		return "", errors.NewGeneralError(fmt.Sprintf("no workspace configured to look up %s '%s', use a GID or --workspace", kind, name), nil)
+	}
+	return cfg.Workspace, nil
+}
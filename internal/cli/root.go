@@ -2,12 +2,15 @@ package cli
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
 	"os"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/cache"
 	"github.com/whoaa512/asana-cli/internal/config"
 	"github.com/whoaa512/asana-cli/internal/errors"
 	"github.com/whoaa512/asana-cli/internal/models"
@@ -18,6 +21,7 @@ var (
 	flagWorkspace  string
 	flagDebug      bool
 	flagDryRun     bool
+	flagNoCache    bool
 	flagTimeout    time.Duration
 	flagConfigPath string
 	flagFormat     string
@@ -34,8 +38,9 @@ Use .asana.json in your repo root to set project/task context.
 Global flags:
   --debug     Print HTTP requests/responses to stderr
   --dry-run   Preview mutations without executing
+  --no-cache  Bypass cached responses (fresh results are still cached)
   --workspace Override workspace GID
-  --format    Output format: json (default), brief`,
+  --format    Output format: json (default), brief, ndjson`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 }
@@ -44,9 +49,10 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&flagWorkspace, "workspace", "w", "", "Override workspace GID")
 	rootCmd.PersistentFlags().BoolVar(&flagDebug, "debug", false, "Print HTTP requests/responses to stderr")
 	rootCmd.PersistentFlags().BoolVar(&flagDryRun, "dry-run", false, "Preview mutations without executing")
+	rootCmd.PersistentFlags().BoolVar(&flagNoCache, "no-cache", false, "Bypass cached responses")
 	rootCmd.PersistentFlags().DurationVar(&flagTimeout, "timeout", 0, "HTTP request timeout (default 30s)")
 	rootCmd.PersistentFlags().StringVar(&flagConfigPath, "config", "", "Config file path (default ~/.config/asana-cli/config.json)")
-	rootCmd.PersistentFlags().StringVar(&flagFormat, "format", "json", "Output format: json, brief")
+	rootCmd.PersistentFlags().StringVar(&flagFormat, "format", "json", "Output format: json, brief, ndjson")
 
 	rootCmd.AddCommand(logCmd)
 	rootCmd.AddCommand(noteCmd)
@@ -80,8 +86,22 @@ var reopenCmd = &cobra.Command{
 	RunE:  runReopen,
 }
 
+// reportedError sets the exit code for a failure whose details were already
+// written to stdout, so Execute does not print a second error document.
+type reportedError struct {
+	exitCode int
+}
+
+func (e *reportedError) Error() string {
+	return fmt.Sprintf("exit status %d", e.exitCode)
+}
+
 func Execute() int {
 	if err := rootCmd.Execute(); err != nil {
+		var reported *reportedError
+		if stderrors.As(err, &reported) {
+			return reported.exitCode
+		}
 		out := newOutput()
 		_ = out.PrintError(err)
 		return errors.GetExitCode(err)
@@ -94,6 +114,7 @@ func loadConfig() (*config.Config, error) {
 		Workspace:  flagWorkspace,
 		Debug:      flagDebug,
 		DryRun:     flagDryRun,
+		NoCache:    flagNoCache,
 		Timeout:    flagTimeout,
 		ConfigPath: flagConfigPath,
 	}
@@ -105,9 +126,40 @@ func newClient(cfg *config.Config) api.Client {
 	if cfg.Debug {
 		opts = append(opts, api.WithDebug(os.Stderr))
 	}
+	if rl := cfg.RateLimit; rl != nil {
+		opts = append(opts, api.WithRateLimits(api.RateLimits{
+			ReadsPerMinute:   rl.ReadsPerMinute,
+			WritesPerMinute:  rl.WritesPerMinute,
+			ConcurrentReads:  rl.ConcurrentReads,
+			ConcurrentWrites: rl.ConcurrentWrites,
+		}))
+	}
+	if cfg.Retry != nil {
+		opts = append(opts, api.WithRetryPolicy(retryPolicy(cfg.Retry)))
+	}
+	opts = append(opts, api.WithCache(cache.New("")))
+	if cfg.NoCache {
+		opts = append(opts, api.WithCacheRefresh())
+	}
 	return api.NewHTTPClient(cfg, opts...)
 }
 
+// retryPolicy applies the configured overrides to the default policy,
+// ignoring unparseable durations as the timeout setting does.
+func retryPolicy(r *config.Retry) api.RetryPolicy {
+	p := api.DefaultRetryPolicy
+	if r.MaxRetries != nil {
+		p.MaxRetries = *r.MaxRetries
+	}
+	if d, err := time.ParseDuration(r.InitialBackoff); err == nil && d > 0 {
+		p.InitialBackoff = d
+	}
+	if d, err := time.ParseDuration(r.MaxBackoff); err == nil && d > 0 {
+		p.MaxBackoff = d
+	}
+	return p
+}
+
 func requireAuth(cfg *config.Config) error {
 	if cfg.AccessToken == "" {
 		return errors.NewAuthError("ASANA_ACCESS_TOKEN environment variable not set")
@@ -1,6 +1,12 @@
 package cli
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
+)
 
 func TestRootCommandExists(t *testing.T) {
 	if rootCmd == nil {
@@ -12,7 +18,7 @@ func TestRootCommandExists(t *testing.T) {
 }
 
 func TestGlobalFlagsRegistered(t *testing.T) {
-	flags := []string{"workspace", "debug", "dry-run", "timeout", "config"}
+	flags := []string{"workspace", "debug", "dry-run", "no-cache", "timeout", "config"}
 	for _, name := range flags {
 		if rootCmd.PersistentFlags().Lookup(name) == nil {
 			t.Errorf("flag %q not registered", name)
@@ -26,3 +32,12 @@ func TestWorkspaceShortFlag(t *testing.T) {
 		t.Error("workspace flag should have -w shorthand")
 	}
 }
+
+func TestRetryPolicy(t *testing.T) {
+	zero := 0
+	got := retryPolicy(&config.Retry{MaxRetries: &zero, MaxBackoff: "5s", InitialBackoff: "soon"})
+	want := api.RetryPolicy{MaxRetries: 0, InitialBackoff: api.DefaultRetryPolicy.InitialBackoff, MaxBackoff: 5 * time.Second}
+	if got != want {
+		t.Errorf("retryPolicy() = %+v, want %+v", got, want)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/whoaa512/asana-cli/internal/api"
 	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/models"
 	"github.com/whoaa512/asana-cli/internal/output"
 )
 
@@ -27,7 +28,10 @@ Requires workspace from config. Use --project or --assignee to narrow results.`,
   asana search "urgent" --assignee me
 
   # Include completed tasks
-  asana search "migration" --completed`,
+  asana search "migration" --completed
+
+  # Filter by custom field
+  asana search "bug" --field "Priority=High" --field "Estimate=3"`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSearch,
 }
@@ -38,16 +42,20 @@ var (
 	searchCompleted bool
 	searchLimit     int
 	searchOffset    string
+	searchFields    []string
+	searchPages     pageFlags
 )
 
 func init() {
 	rootCmd.AddCommand(searchCmd)
 
-	searchCmd.Flags().StringVar(&searchProject, "project", "", "Filter by project GID")
-	searchCmd.Flags().StringVar(&searchAssignee, "assignee", "", "Filter by assignee GID or 'me'")
+	searchCmd.Flags().StringVar(&searchProject, "project", "", "Filter by project GID or name")
+	searchCmd.Flags().StringVar(&searchAssignee, "assignee", "", "Filter by assignee GID, email, name or 'me'")
 	searchCmd.Flags().BoolVar(&searchCompleted, "completed", false, "Include completed tasks")
 	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "Max results to return")
 	searchCmd.Flags().StringVar(&searchOffset, "offset", "", "Pagination offset")
+	searchCmd.Flags().StringArrayVar(&searchFields, "field", nil, "Filter by custom field, e.g. \"Priority=High\" (repeatable)")
+	addPageFlags(searchCmd, &searchPages)
 }
 
 func runSearch(_ *cobra.Command, args []string) error {
@@ -63,11 +71,23 @@ func runSearch(_ *cobra.Command, args []string) error {
 		return errors.NewGeneralError("workspace is required for search", nil)
 	}
 
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	project, err := resolveProjectGID(ctx, cfg, client, searchProject, false)
+	if err != nil {
+		return err
+	}
+	assignee, err := resolveUserGID(ctx, cfg, client, searchAssignee, false)
+	if err != nil {
+		return err
+	}
+
 	opts := api.SearchTasksOptions{
 		Workspace: cfg.Workspace,
 		Text:      args[0],
-		Project:   searchProject,
-		Assignee:  searchAssignee,
+		Project:   project,
+		Assignee:  assignee,
 		Limit:     searchLimit,
 		Offset:    searchOffset,
 		OptFields: []string{"name", "completed"},
@@ -78,17 +98,30 @@ func runSearch(_ *cobra.Command, args []string) error {
 		opts.Completed = &completed
 	}
 
+	fields, err := parseFieldArgs(searchFields)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		var definitions []models.CustomField
+		if project != "" {
+			definitions, err = projectCustomFields(ctx, client, project)
+		} else {
+			definitions, err = workspaceCustomFields(ctx, client, cfg.Workspace)
+		}
+		if err != nil {
+			return err
+		}
+		opts.CustomFields, err = searchCustomFieldParams(definitions, fields, fieldUserResolver(ctx, cfg, client))
+		if err != nil {
+			return err
+		}
+	}
+
 	if cfg.DryRun {
 		out := output.NewJSON(os.Stdout)
 		return out.Print(map[string]any{"dry_run": true, "search_options": opts})
 	}
 
-	client := newClient(cfg)
-	result, err := client.SearchTasks(context.Background(), opts)
-	if err != nil {
-		return err
-	}
-
-	out := newOutput()
-	return out.PrintTaskList(result)
+	return printList(ctx, &searchPages, searchLimit, searchOffset, api.SearchPages(client, opts))
 }
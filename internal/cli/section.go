@@ -39,9 +39,10 @@ var sectionCreateCmd = &cobra.Command{
 }
 
 var sectionAddTaskCmd = &cobra.Command{
-	Use:   "add-task <section-gid> <task-gid>",
-	Short: "Add a task to a section",
-	Args:  cobra.ExactArgs(2),
+	Use:   "add-task <section-gid> <task-gid>...",
+	Short: "Add tasks to a section",
+	Long:  "Add one or more tasks to a section. Multiple tasks are moved through the batch API.",
+	Args:  cobra.MinimumNArgs(2),
 	RunE:  runSectionAddTask,
 }
 
@@ -60,8 +61,9 @@ var sectionDeleteCmd = &cobra.Command{
 }
 
 var sectionInsertCmd = &cobra.Command{
-	Use:   "insert <section-gid>",
+	Use:   "insert <section>",
 	Short: "Reorder a section within a project",
+	Long:  "Move a section, given by GID or name, before or after another section of the project.",
 	Args:  cobra.ExactArgs(1),
 	RunE:  runSectionInsert,
 }
@@ -70,6 +72,7 @@ var (
 	sectionListProject   string
 	sectionListLimit     int
 	sectionListOffset    string
+	sectionListPages     pageFlags
 	sectionCreateProject string
 	sectionCreateName    string
 	sectionUpdateName    string
@@ -88,11 +91,12 @@ func init() {
 	sectionCmd.AddCommand(sectionInsertCmd)
 	sectionCmd.AddCommand(sectionAddTaskCmd)
 
-	sectionListCmd.Flags().StringVar(&sectionListProject, "project", "", "Project GID")
+	sectionListCmd.Flags().StringVar(&sectionListProject, "project", "", "Project GID or name (default from context)")
 	sectionListCmd.Flags().IntVar(&sectionListLimit, "limit", 50, "Max results to return")
 	sectionListCmd.Flags().StringVar(&sectionListOffset, "offset", "", "Pagination offset")
+	addPageFlags(sectionListCmd, &sectionListPages)
 
-	sectionCreateCmd.Flags().StringVar(&sectionCreateProject, "project", "", "Project GID")
+	sectionCreateCmd.Flags().StringVar(&sectionCreateProject, "project", "", "Project GID or name (default from context)")
 	sectionCreateCmd.Flags().StringVar(&sectionCreateName, "name", "", "Section name (required)")
 	if err := sectionCreateCmd.MarkFlagRequired("name"); err != nil {
 		panic(err)
@@ -103,9 +107,9 @@ func init() {
 		panic(err)
 	}
 
-	sectionInsertCmd.Flags().StringVar(&sectionInsertProject, "project", "", "Project GID")
-	sectionInsertCmd.Flags().StringVar(&sectionInsertBefore, "before", "", "Insert before this section GID")
-	sectionInsertCmd.Flags().StringVar(&sectionInsertAfter, "after", "", "Insert after this section GID")
+	sectionInsertCmd.Flags().StringVar(&sectionInsertProject, "project", "", "Project GID or name (default from context)")
+	sectionInsertCmd.Flags().StringVar(&sectionInsertBefore, "before", "", "Insert before this section GID or name")
+	sectionInsertCmd.Flags().StringVar(&sectionInsertAfter, "after", "", "Insert after this section GID or name")
 }
 
 func runSectionList(_ *cobra.Command, _ []string) error {
@@ -117,9 +121,12 @@ func runSectionList(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
-	project := sectionListProject
-	if project == "" && cfg.Project != "" {
-		project = cfg.Project
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	project, err := resolveProjectFlag(ctx, cfg, client, sectionListProject, false)
+	if err != nil {
+		return err
 	}
 	if project == "" {
 		return errors.NewGeneralError("no project specified (use --project or set in .asana.json)", nil)
@@ -127,18 +134,9 @@ func runSectionList(_ *cobra.Command, _ []string) error {
 
 	opts := api.SectionListOptions{
 		Project: project,
-		Limit:   sectionListLimit,
-		Offset:  sectionListOffset,
 	}
 
-	client := newClient(cfg)
-	result, err := client.ListSections(context.Background(), opts)
-	if err != nil {
-		return err
-	}
-
-	out := output.NewJSON(os.Stdout)
-	return out.Print(result)
+	return printList(ctx, &sectionListPages, sectionListLimit, sectionListOffset, api.SectionPages(client, opts))
 }
 
 func runSectionGet(_ *cobra.Command, args []string) error {
@@ -169,9 +167,12 @@ func runSectionCreate(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
-	project := sectionCreateProject
-	if project == "" && cfg.Project != "" {
-		project = cfg.Project
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	project, err := resolveProjectFlag(ctx, cfg, client, sectionCreateProject, false)
+	if err != nil {
+		return err
 	}
 	if project == "" {
 		return errors.NewGeneralError("no project specified (use --project or set in .asana.json)", nil)
@@ -186,8 +187,7 @@ func runSectionCreate(_ *cobra.Command, _ []string) error {
 		return out.Print(map[string]any{"dry_run": true, "project": project, "request": req})
 	}
 
-	client := newClient(cfg)
-	section, err := client.CreateSection(context.Background(), project, req)
+	section, err := client.CreateSection(ctx, project, req)
 	if err != nil {
 		return err
 	}
@@ -206,20 +206,51 @@ func runSectionAddTask(_ *cobra.Command, args []string) error {
 	}
 
 	sectionGID := args[0]
-	taskGID := args[1]
+	taskGIDs := args[1:]
 
 	if cfg.DryRun {
 		out := output.NewJSON(os.Stdout)
-		return out.Print(map[string]any{"dry_run": true, "section": sectionGID, "task": taskGID})
+		if len(taskGIDs) == 1 {
+			return out.Print(map[string]any{"dry_run": true, "section": sectionGID, "task": taskGIDs[0]})
+		}
+		return out.Print(map[string]any{"dry_run": true, "section": sectionGID, "tasks": taskGIDs})
 	}
 
 	client := newClient(cfg)
-	if err := client.AddTaskToSection(context.Background(), sectionGID, taskGID); err != nil {
+	ctx := context.Background()
+
+	if len(taskGIDs) == 1 {
+		if err := client.AddTaskToSection(ctx, sectionGID, taskGIDs[0]); err != nil {
+			return err
+		}
+
+		out := output.NewJSON(os.Stdout)
+		return out.Print(map[string]any{"success": true, "section": sectionGID, "task": taskGIDs[0]})
+	}
+
+	actions := make([]taskAction, len(taskGIDs))
+	for i, taskGID := range taskGIDs {
+		actions[i] = taskAction{TaskGID: taskGID, Action: api.AddTaskToSectionAction(sectionGID, taskGID)}
+	}
+
+	results, failed, err := applyTaskActions(ctx, client, actions, 1)
+	if err != nil {
 		return err
 	}
 
 	out := output.NewJSON(os.Stdout)
-	return out.Print(map[string]any{"success": true, "section": sectionGID, "task": taskGID})
+	if err := out.Print(map[string]any{
+		"section":   sectionGID,
+		"results":   results,
+		"succeeded": len(results) - failed,
+		"failed":    failed,
+	}); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return &reportedError{exitCode: errors.ExitGeneral}
+	}
+	return nil
 }
 
 func runSectionUpdate(_ *cobra.Command, args []string) error {
@@ -282,29 +313,49 @@ func runSectionInsert(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	project := sectionInsertProject
-	if project == "" && cfg.Project != "" {
-		project = cfg.Project
+	if sectionInsertBefore == "" && sectionInsertAfter == "" {
+		return errors.NewGeneralError("must specify either --before or --after", nil)
+	}
+	if sectionInsertBefore != "" && sectionInsertAfter != "" {
+		return errors.NewGeneralError("cannot specify both --before and --after", nil)
+	}
+
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	project, err := resolveProjectFlag(ctx, cfg, client, sectionInsertProject, false)
+	if err != nil {
+		return err
 	}
 	if project == "" {
 		return errors.NewGeneralError("no project specified (use --project or set in .asana.json)", nil)
 	}
 
-	if sectionInsertBefore == "" && sectionInsertAfter == "" {
-		return errors.NewGeneralError("must specify either --before or --after", nil)
+	// Sections are looked up by name in the project they are reordered in.
+	projects := func(context.Context) ([]models.AsanaResource, error) {
+		return []models.AsanaResource{{GID: project}}, nil
 	}
-	if sectionInsertBefore != "" && sectionInsertAfter != "" {
-		return errors.NewGeneralError("cannot specify both --before and --after", nil)
+	section, err := resolveSectionGID(ctx, cfg, client, projects, args[0], false)
+	if err != nil {
+		return err
+	}
+	before, err := resolveSectionGID(ctx, cfg, client, projects, sectionInsertBefore, false)
+	if err != nil {
+		return err
+	}
+	after, err := resolveSectionGID(ctx, cfg, client, projects, sectionInsertAfter, false)
+	if err != nil {
+		return err
 	}
 
 	req := models.SectionInsertRequest{
-		Section: args[0],
+		Section: section,
 	}
-	if sectionInsertBefore != "" {
-		req.BeforeSection = &sectionInsertBefore
+	if before != "" {
+		req.BeforeSection = &before
 	}
-	if sectionInsertAfter != "" {
-		req.AfterSection = &sectionInsertAfter
+	if after != "" {
+		req.AfterSection = &after
 	}
 
 	if cfg.DryRun {
@@ -312,8 +363,7 @@ func runSectionInsert(_ *cobra.Command, args []string) error {
 		return out.Print(map[string]any{"dry_run": true, "project": project, "request": req})
 	}
 
-	client := newClient(cfg)
-	if err := client.InsertSection(context.Background(), project, req); err != nil {
+	if err := client.InsertSection(ctx, project, req); err != nil {
 		return err
 	}
 
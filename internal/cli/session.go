@@ -2,7 +2,10 @@ package cli
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -23,7 +26,9 @@ var sessionStartCmd = &cobra.Command{
 	Short: "Start a work session",
 	Long: `Begin a work session on a task. Captures git branch and records start time.
 
-Session data is stored in .asana-cli/session.json in the repo root.
+Session data is stored in .asana-cli/sessions/<task-gid>.json in the repo
+root, shared by all worktrees. The new session becomes current in this
+worktree; sessions on other tasks are kept (see 'session list').
 Use 'session log' to add progress notes during work.
 End with 'session end' to post a summary comment to Asana.`,
 	Example: `  # Start session on a specific task
@@ -33,8 +38,8 @@ End with 'session end' to post a summary comment to Asana.`,
   asana ctx task 1234567890
   asana session start
 
-  # Force start, discarding existing session
-  asana session start --force`,
+  # Restart a task's session, discarding the old one
+  asana session start 1234567890 --force`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runSessionStart,
 }
@@ -71,6 +76,48 @@ var sessionLogCmd = &cobra.Command{
 	RunE:  runSessionLog,
 }
 
+var sessionPauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Pause the current session",
+	Long: `Stop counting active time until 'session resume'.
+
+Sessions also pause on their own once nothing has been logged for the idle
+timeout (1h by default, set with "session": {"idle_timeout": "30m"} in the
+global config, "0" to disable). Active time then ends at the last log, and
+the next 'session log' resumes the session.`,
+	Args: cobra.NoArgs,
+	RunE: runSessionPause,
+}
+
+var sessionResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume a paused session",
+	Args:  cobra.NoArgs,
+	RunE:  runSessionResume,
+}
+
+var sessionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List sessions in this repo",
+	Long: `List every open session in the repo, across all of its worktrees.
+
+The session marked current is the one 'session log', 'session end' and the
+other session commands act on in this worktree.`,
+	Args: cobra.NoArgs,
+	RunE: runSessionList,
+}
+
+var sessionSwitchCmd = &cobra.Command{
+	Use:   "switch <task-gid>",
+	Short: "Make another session current",
+	Long:  "Make the session for a task the current one in this worktree, and set it as the context task.",
+	Example: `  # Continue a session started in another worktree
+  asana session list
+  asana session switch 1234567890`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionSwitch,
+}
+
 var (
 	sessionStartForce bool
 	sessionEndSummary string
@@ -84,8 +131,12 @@ func init() {
 	sessionCmd.AddCommand(sessionEndCmd)
 	sessionCmd.AddCommand(sessionStatusCmd)
 	sessionCmd.AddCommand(sessionLogCmd)
+	sessionCmd.AddCommand(sessionPauseCmd)
+	sessionCmd.AddCommand(sessionResumeCmd)
+	sessionCmd.AddCommand(sessionListCmd)
+	sessionCmd.AddCommand(sessionSwitchCmd)
 
-	sessionStartCmd.Flags().BoolVar(&sessionStartForce, "force", false, "Force start, discarding existing session")
+	sessionStartCmd.Flags().BoolVar(&sessionStartForce, "force", false, "Discard an existing session for the task and start over")
 
 	sessionEndCmd.Flags().StringVar(&sessionEndSummary, "summary", "", "Additional summary text")
 	sessionEndCmd.Flags().BoolVar(&sessionEndDiscard, "discard", false, "Discard session without posting to Asana")
@@ -93,52 +144,103 @@ func init() {
 	sessionLogCmd.Flags().StringVar(&sessionLogType, "type", "progress", "Log type: progress, decision, blocker")
 }
 
-func getSessionDir() (string, error) {
-	root := session.GetRepoRoot()
-	if root != "" {
-		return root, nil
+// sessionStore opens the sessions of the current repo, shared by all of its
+// worktrees, with the current session tracked in each worktree's git dir.
+// Outside a repo it falls back to the global config dir.
+func sessionStore() (*session.Store, error) {
+	if root := session.GetMainRepoRoot(); root != "" {
+		var current string
+		if gitDir := session.GetGitDir(); gitDir != "" {
+			current = filepath.Join(gitDir, session.CurrentFile)
+		}
+		return session.NewStore(root, current), nil
 	}
 
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	fallback := home + "/.config/asana-cli"
 	if err := os.MkdirAll(fallback, 0755); err != nil {
-		return "", err
+		return nil, err
+	}
+	return session.NewStore(fallback, ""), nil
+}
+
+// sessionIdleTimeout returns the configured idle timeout, or the default
+// when unset or invalid.
+func sessionIdleTimeout(cfg *config.Config) time.Duration {
+	if cfg == nil || cfg.Session == nil || cfg.Session.IdleTimeout == "" {
+		return session.DefaultIdleTimeout
 	}
-	return fallback, nil
+	d, err := time.ParseDuration(cfg.Session.IdleTimeout)
+	if err != nil || d < 0 {
+		return session.DefaultIdleTimeout
+	}
+	return d
+}
+
+// loadSession loads the worktree's current session and pauses it if it has
+// gone idle, saving the pause so active time stops at the last log.
+func loadSession(cfg *config.Config, store *session.Store) (*session.Session, error) {
+	sess, err := store.Current()
+	if err != nil || sess == nil {
+		return sess, err
+	}
+	timeout := sessionIdleTimeout(cfg)
+	if !sess.PauseIfIdle(timeout, time.Now()) || cfg.DryRun {
+		return sess, nil
+	}
+	return store.Update(sess.TaskGID, func(s *session.Session) error {
+		s.PauseIfIdle(timeout, time.Now())
+		return nil
+	})
 }
 
 func runSessionStart(_ *cobra.Command, args []string) error {
-	dir, err := getSessionDir()
-	if err != nil {
-		return errors.NewGeneralError("failed to determine session directory", err)
+	var taskGID string
+	if len(args) > 0 {
+		taskGID = args[0]
 	}
 
-	existing, err := session.Load(dir)
+	result, err := startSession(taskGID, sessionStartForce)
 	if err != nil {
-		return errors.NewGeneralError("failed to load existing session", err)
+		return err
 	}
 
-	if existing != nil && !sessionStartForce {
-		return errors.NewGeneralError("session already exists, use --force to override", nil)
+	out := output.NewJSON(os.Stdout)
+	return out.Print(result)
+}
+
+// startSession begins a session on taskGID, or on the context task when
+// taskGID is empty, and makes it the worktree's current session. Sessions
+// on other tasks are kept.
+func startSession(taskGID string, force bool) (map[string]any, error) {
+	store, err := sessionStore()
+	if err != nil {
+		return nil, errors.NewGeneralError("failed to determine session directory", err)
 	}
 
 	localCtx, err := config.LoadLocalContext()
 	if err != nil {
-		return errors.NewGeneralError("failed to load context", err)
+		return nil, errors.NewGeneralError("failed to load context", err)
 	}
 
-	var taskGID string
-	if len(args) > 0 {
-		taskGID = args[0]
-	} else {
+	if taskGID == "" {
 		taskGID = localCtx.Task
 	}
 
 	if taskGID == "" {
-		return errors.NewInvalidArgsError("task-gid required (provide as argument or set via 'ctx task')")
+		return nil, errors.NewInvalidArgsError("task-gid required (provide as argument or set via 'ctx task')")
+	}
+
+	existing, err := store.Get(taskGID)
+	if err != nil {
+		return nil, errors.NewGeneralError("failed to load existing session", err)
+	}
+
+	if existing != nil && !force {
+		return nil, errors.NewGeneralError(fmt.Sprintf("a session for task %s already exists, use 'session switch %s' to continue it or --force to start over", taskGID, taskGID), nil)
 	}
 
 	var opts []session.SessionOption
@@ -151,24 +253,33 @@ func runSessionStart(_ *cobra.Command, args []string) error {
 	if localCtx.Project != "" {
 		opts = append(opts, session.WithProject(localCtx.Project))
 	}
+	if worktree := session.GetRepoRoot(); worktree != "" {
+		opts = append(opts, session.WithWorktree(worktree))
+	}
+	if sha := session.GetHeadSHA(); sha != "" {
+		opts = append(opts, session.WithStartSHA(sha))
+	}
 
 	sess := session.New(taskGID, opts...)
-	if err := sess.Save(dir); err != nil {
-		return errors.NewGeneralError("failed to save session", err)
+	if err := store.Save(sess); err != nil {
+		return nil, errors.NewGeneralError("failed to save session", err)
+	}
+	if err := store.SetCurrent(taskGID); err != nil {
+		return nil, errors.NewGeneralError("failed to save session", err)
 	}
 
 	if err := updateContextTask(taskGID); err != nil {
-		return errors.NewGeneralError("failed to update context", err)
+		return nil, errors.NewGeneralError("failed to update context", err)
 	}
 
-	out := output.NewJSON(os.Stdout)
-	return out.Print(map[string]any{
+	return map[string]any{
 		"task_gid":     sess.TaskGID,
 		"started_at":   sess.StartedAt,
 		"git_branch":   sess.StartBranch,
+		"start_sha":    sess.StartSHA,
 		"repo":         sess.Repo,
 		"session_path": sess.Path(),
-	})
+	}, nil
 }
 
 func updateContextTask(taskGID string) error {
@@ -194,111 +305,137 @@ func runSessionEnd(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
-	dir, err := getSessionDir()
+	result, err := endSession(cfg, sessionEndSummary, sessionEndDiscard)
 	if err != nil {
-		return errors.NewGeneralError("failed to determine session directory", err)
+		return err
+	}
+
+	out := output.NewJSON(os.Stdout)
+	return out.Print(result)
+}
+
+// endSession posts the session summary to Asana (unless discard is set or
+// there is nothing to post) and moves the session to the archive. Discarded
+// sessions are deleted without being archived.
+func endSession(cfg *config.Config, extraSummary string, discard bool) (map[string]any, error) {
+	store, err := sessionStore()
+	if err != nil {
+		return nil, errors.NewGeneralError("failed to determine session directory", err)
 	}
 
-	sess, err := session.Load(dir)
+	sess, err := loadSession(cfg, store)
 	if err != nil {
-		return errors.NewGeneralError("failed to load session", err)
+		return nil, errors.NewGeneralError("failed to load session", err)
 	}
 	if sess == nil {
-		return errors.NewGeneralError("no active session", nil)
+		return nil, errors.NewGeneralError("no active session", nil)
 	}
 
-	if sessionEndDiscard {
+	// Fold logged entries into the session file, so a session kept after
+	// a failed post is a single file again.
+	if !cfg.DryRun {
+		if err := store.Compact(sess.TaskGID); err != nil {
+			return nil, errors.NewGeneralError("failed to save session", err)
+		}
+	}
+
+	if discard {
 		if cfg.DryRun {
-			out := output.NewJSON(os.Stdout)
-			return out.Print(map[string]any{
+			return map[string]any{
 				"dry_run":      true,
 				"action":       "discard",
 				"task_gid":     sess.TaskGID,
 				"session_path": sess.Path(),
-			})
+			}, nil
 		}
-		if err := session.Delete(dir); err != nil {
-			return errors.NewGeneralError("failed to delete session", err)
+		if err := store.Delete(sess.TaskGID); err != nil {
+			return nil, errors.NewGeneralError("failed to delete session", err)
 		}
-		out := output.NewJSON(os.Stdout)
-		return out.Print(map[string]any{
+		return map[string]any{
 			"discarded":    true,
 			"task_gid":     sess.TaskGID,
 			"duration":     sess.FormatDuration(),
 			"session_path": sess.Path(),
-		})
+		}, nil
 	}
 
-	if !sess.HasLogs() && sessionEndSummary == "" {
+	endBranch := session.GetCurrentBranch()
+	sess.RecordGitActivity()
+
+	if !sess.HasLogs() && extraSummary == "" {
 		if cfg.DryRun {
-			out := output.NewJSON(os.Stdout)
-			return out.Print(map[string]any{
+			return map[string]any{
 				"dry_run":      true,
 				"action":       "end_no_post",
 				"task_gid":     sess.TaskGID,
 				"reason":       "no logs or summary to post",
 				"session_path": sess.Path(),
-			})
+			}, nil
 		}
-		if err := session.Delete(dir); err != nil {
-			return errors.NewGeneralError("failed to delete session", err)
+		rec := &session.Record{Session: *sess, EndedAt: time.Now(), EndBranch: endBranch}
+		if err := store.End(rec); err != nil {
+			return nil, errors.NewGeneralError("failed to archive session", err)
 		}
-		out := output.NewJSON(os.Stdout)
-		return out.Print(map[string]any{
+		return map[string]any{
 			"ended":        true,
 			"task_gid":     sess.TaskGID,
-			"duration":     sess.FormatDuration(),
+			"duration":     rec.FormatDuration(),
 			"posted":       false,
 			"reason":       "no logs or summary to post",
+			"archive_id":   rec.ID,
 			"session_path": sess.Path(),
-		})
+		}, nil
 	}
 
-	endBranch := session.GetCurrentBranch()
-	summary := sess.FormatSummary(endBranch, sessionEndSummary)
+	summary := sess.FormatSummary(endBranch, extraSummary)
 
 	if cfg.DryRun {
-		out := output.NewJSON(os.Stdout)
-		return out.Print(map[string]any{
+		return map[string]any{
 			"dry_run":      true,
 			"action":       "end_and_post",
 			"task_gid":     sess.TaskGID,
 			"duration":     sess.FormatDuration(),
+			"commits":      len(sess.Commits),
 			"summary":      summary,
 			"session_path": sess.Path(),
-		})
+		}, nil
 	}
 
 	client := newClient(cfg)
 	story, err := client.AddComment(context.Background(), sess.TaskGID, summary)
 	if err != nil {
-		return errors.NewGeneralError("failed to post summary to Asana (session preserved, use --discard to clear)", err)
+		return nil, errors.NewGeneralError("failed to post summary to Asana (session preserved, use --discard to clear)", err)
 	}
 
-	if err := session.Delete(dir); err != nil {
-		return errors.NewGeneralError("failed to delete session", err)
+	rec := &session.Record{Session: *sess, EndedAt: time.Now(), EndBranch: endBranch, Summary: extraSummary, StoryGID: story.GID}
+	if err := store.End(rec); err != nil {
+		return nil, errors.NewGeneralError("failed to archive session", err)
 	}
 
-	result := map[string]any{
+	return map[string]any{
 		"ended":        true,
 		"task_gid":     sess.TaskGID,
-		"duration":     sess.FormatDuration(),
+		"duration":     rec.FormatDuration(),
 		"posted":       true,
 		"story_gid":    story.GID,
+		"commits":      len(sess.Commits),
+		"archive_id":   rec.ID,
 		"session_path": sess.Path(),
-	}
-
-	out := output.NewJSON(os.Stdout)
-	return out.Print(result)
+	}, nil
 }
 
 func runSessionStatus(_ *cobra.Command, _ []string) error {
-	dir, err := getSessionDir()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	store, err := sessionStore()
 	if err != nil {
 		return errors.NewGeneralError("failed to determine session directory", err)
 	}
 
-	sess, err := session.Load(dir)
+	sess, err := loadSession(cfg, store)
 	if err != nil {
 		return errors.NewGeneralError("failed to load session", err)
 	}
@@ -311,9 +448,14 @@ func runSessionStatus(_ *cobra.Command, _ []string) error {
 		"active":       true,
 		"task_gid":     sess.TaskGID,
 		"started_at":   sess.StartedAt,
-		"elapsed":      sess.FormatDuration(),
+		"elapsed":      sess.FormatElapsed(),
+		"active_time":  sess.FormatDuration(),
+		"paused":       sess.Paused(),
+		"paused_at":    sess.PausedAt(),
 		"git_branch":   sess.StartBranch,
+		"start_sha":    sess.StartSHA,
 		"repo":         sess.Repo,
+		"worktree":     sess.Worktree,
 		"log_count":    len(sess.Logs),
 		"logs":         sess.Logs,
 		"stale":        sess.IsStale(),
@@ -325,36 +467,228 @@ func runSessionStatus(_ *cobra.Command, _ []string) error {
 }
 
 func runSessionLog(_ *cobra.Command, args []string) error {
-	dir, err := getSessionDir()
+	cfg, err := loadConfig()
 	if err != nil {
-		return errors.NewGeneralError("failed to determine session directory", err)
+		return err
 	}
 
-	sess, err := session.Load(dir)
+	result, err := logSession(cfg, sessionLogType, args[0])
 	if err != nil {
-		return errors.NewGeneralError("failed to load session", err)
+		return err
+	}
+
+	out := output.NewJSON(os.Stdout)
+	return out.Print(result)
+}
+
+// logSession records a note, resuming the session if it was paused for
+// being idle. A session paused by hand stays paused.
+func logSession(cfg *config.Config, logType, message string) (map[string]any, error) {
+	store, err := sessionStore()
+	if err != nil {
+		return nil, errors.NewGeneralError("failed to determine session directory", err)
+	}
+
+	sess, err := loadSession(cfg, store)
+	if err != nil {
+		return nil, errors.NewGeneralError("failed to load session", err)
 	}
 	if sess == nil {
-		return errors.NewGeneralError("no active session, start one with 'session start'", nil)
+		return nil, errors.NewGeneralError("no active session, start one with 'session start'", nil)
 	}
 
 	validTypes := map[string]bool{"progress": true, "decision": true, "blocker": true}
-	if !validTypes[sessionLogType] {
-		return errors.NewInvalidArgsError("invalid log type, must be: progress, decision, or blocker")
+	if !validTypes[logType] {
+		return nil, errors.NewInvalidArgsError("invalid log type, must be: progress, decision, or blocker")
 	}
 
-	sess.AddLog(sessionLogType, args[0])
+	if sess.Paused() && sess.PausedForIdle() {
+		sess, err = store.Update(sess.TaskGID, func(s *session.Session) error {
+			if s.Paused() && s.PausedForIdle() {
+				_ = s.Resume(time.Now())
+			}
+			s.AddLog(logType, message)
+			return nil
+		})
+	} else {
+		sess, err = store.AppendLog(sess.TaskGID, logType, message)
+	}
+	if err != nil {
+		return nil, errors.NewGeneralError("failed to save session", err)
+	}
 
-	if err := sess.Save(dir); err != nil {
-		return errors.NewGeneralError("failed to save session", err)
+	return map[string]any{
+		"logged":       true,
+		"type":         logType,
+		"message":      message,
+		"log_count":    len(sess.Logs),
+		"paused":       sess.Paused(),
+		"session_path": sess.Path(),
+	}, nil
+}
+
+func runSessionPause(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	result, err := pauseSession(cfg, true)
+	if err != nil {
+		return err
+	}
+
+	out := output.NewJSON(os.Stdout)
+	return out.Print(result)
+}
+
+func runSessionResume(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	result, err := pauseSession(cfg, false)
+	if err != nil {
+		return err
 	}
 
 	out := output.NewJSON(os.Stdout)
+	return out.Print(result)
+}
+
+// pauseSession pauses the current session, or resumes it when pause is
+// false.
+func pauseSession(cfg *config.Config, pause bool) (map[string]any, error) {
+	store, err := sessionStore()
+	if err != nil {
+		return nil, errors.NewGeneralError("failed to determine session directory", err)
+	}
+
+	sess, err := loadSession(cfg, store)
+	if err != nil {
+		return nil, errors.NewGeneralError("failed to load session", err)
+	}
+	if sess == nil {
+		return nil, errors.NewGeneralError("no active session, start one with 'session start'", nil)
+	}
+
+	action := "resume"
+	change := func(s *session.Session) error { return s.Resume(time.Now()) }
+	if pause {
+		action = "pause"
+		change = func(s *session.Session) error { return s.Pause(time.Now()) }
+	}
+	if err := change(sess); err != nil {
+		return nil, errors.NewGeneralError(err.Error(), nil)
+	}
+
+	if cfg.DryRun {
+		return map[string]any{
+			"dry_run":      true,
+			"action":       action,
+			"task_gid":     sess.TaskGID,
+			"active_time":  sess.FormatDuration(),
+			"session_path": sess.Path(),
+		}, nil
+	}
+
+	sess, err = store.Update(sess.TaskGID, change)
+	if err != nil {
+		return nil, errors.NewGeneralError("failed to save session", err)
+	}
+
+	return map[string]any{
+		"paused":       sess.Paused(),
+		"task_gid":     sess.TaskGID,
+		"active_time":  sess.FormatDuration(),
+		"session_path": sess.Path(),
+	}, nil
+}
+
+func runSessionList(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	store, err := sessionStore()
+	if err != nil {
+		return errors.NewGeneralError("failed to determine session directory", err)
+	}
+
+	sessions, err := store.List()
+	if err != nil {
+		return errors.NewGeneralError("failed to load sessions", err)
+	}
+
+	current := store.CurrentGID()
+	timeout := sessionIdleTimeout(cfg)
+	result := make([]map[string]any, 0, len(sessions))
+	for _, sess := range sessions {
+		// Reported as paused, but only saved when the session is next used.
+		sess.PauseIfIdle(timeout, time.Now())
+		result = append(result, map[string]any{
+			"task_gid":     sess.TaskGID,
+			"current":      sess.TaskGID == current,
+			"started_at":   sess.StartedAt,
+			"active_time":  sess.FormatDuration(),
+			"paused":       sess.Paused(),
+			"git_branch":   sess.StartBranch,
+			"worktree":     sess.Worktree,
+			"log_count":    len(sess.Logs),
+			"session_path": sess.Path(),
+		})
+	}
+
+	out := output.NewJSON(os.Stdout)
+	return out.Print(result)
+}
+
+func runSessionSwitch(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	store, err := sessionStore()
+	if err != nil {
+		return errors.NewGeneralError("failed to determine session directory", err)
+	}
+
+	sess, err := store.Get(args[0])
+	if err != nil {
+		return errors.NewGeneralError("failed to load session", err)
+	}
+	if sess == nil {
+		return errors.NewGeneralError(fmt.Sprintf("no session for task %s, see 'session list'", args[0]), nil)
+	}
+
+	out := output.NewJSON(os.Stdout)
+	if cfg.DryRun {
+		return out.Print(map[string]any{
+			"dry_run":      true,
+			"action":       "switch",
+			"from":         store.CurrentGID(),
+			"task_gid":     sess.TaskGID,
+			"session_path": sess.Path(),
+		})
+	}
+
+	from := store.CurrentGID()
+	if err := store.SetCurrent(sess.TaskGID); err != nil {
+		return errors.NewGeneralError("failed to switch session", err)
+	}
+	if err := updateContextTask(sess.TaskGID); err != nil {
+		return errors.NewGeneralError("failed to update context", err)
+	}
+
 	return out.Print(map[string]any{
-		"logged":       true,
-		"type":         sessionLogType,
-		"message":      args[0],
-		"log_count":    len(sess.Logs),
+		"switched":     true,
+		"from":         from,
+		"task_gid":     sess.TaskGID,
+		"active_time":  sess.FormatDuration(),
+		"paused":       sess.Paused(),
 		"session_path": sess.Path(),
 	})
 }
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/output"
+	"github.com/whoaa512/asana-cli/internal/session"
+)
+
+var sessionHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List ended sessions",
+	Long: `List sessions ended in this repo, most recent first.
+
+Ended sessions are archived in .asana-cli/archive/ with their logs, commits
+and the GID of the posted summary. Discarded sessions are not kept.`,
+	Example: `  # Sessions from the last week
+  asana session history --since 7d
+
+  # Every session on a task
+  asana session history --task 1234567890 --limit 0`,
+	Args: cobra.NoArgs,
+	RunE: runSessionHistory,
+}
+
+var sessionShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show an ended session",
+	Long:  "Show an archived session by the id from 'session history', or the latest one for a task GID.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSessionShow,
+}
+
+var (
+	sessionHistoryTask  string
+	sessionHistorySince string
+	sessionHistoryLimit int
+)
+
+func init() {
+	sessionCmd.AddCommand(sessionHistoryCmd)
+	sessionCmd.AddCommand(sessionShowCmd)
+
+	sessionHistoryCmd.Flags().StringVar(&sessionHistoryTask, "task", "", "Only sessions on this task GID")
+	sessionHistoryCmd.Flags().StringVar(&sessionHistorySince, "since", "", "Only sessions ended after a date (2024-01-15), time (RFC 3339) or age (7d, 12h)")
+	sessionHistoryCmd.Flags().IntVar(&sessionHistoryLimit, "limit", 20, "Max results to return (0 for all)")
+}
+
+func runSessionHistory(_ *cobra.Command, _ []string) error {
+	since, err := parseSince(sessionHistorySince, time.Now())
+	if err != nil {
+		return err
+	}
+
+	store, err := sessionStore()
+	if err != nil {
+		return errors.NewGeneralError("failed to determine session directory", err)
+	}
+
+	records, err := store.History()
+	if err != nil {
+		return errors.NewGeneralError("failed to load session history", err)
+	}
+
+	result := []map[string]any{}
+	for _, rec := range records {
+		if sessionHistoryTask != "" && rec.TaskGID != sessionHistoryTask {
+			continue
+		}
+		if !since.IsZero() && rec.EndedAt.Before(since) {
+			continue
+		}
+		if sessionHistoryLimit > 0 && len(result) == sessionHistoryLimit {
+			break
+		}
+		result = append(result, map[string]any{
+			"id":          rec.ID,
+			"task_gid":    rec.TaskGID,
+			"repo":        rec.Repo,
+			"git_branch":  rec.StartBranch,
+			"started_at":  rec.StartedAt,
+			"ended_at":    rec.EndedAt,
+			"active_time": rec.FormatDuration(),
+			"log_count":   len(rec.Logs),
+			"commits":     len(rec.Commits),
+			"story_gid":   rec.StoryGID,
+		})
+	}
+
+	out := output.NewJSON(os.Stdout)
+	return out.Print(result)
+}
+
+func runSessionShow(_ *cobra.Command, args []string) error {
+	store, err := sessionStore()
+	if err != nil {
+		return errors.NewGeneralError("failed to determine session directory", err)
+	}
+
+	rec, err := store.Archived(args[0])
+	if err != nil {
+		return errors.NewGeneralError("failed to load session", err)
+	}
+	if rec == nil {
+		// Not an id, so try it as a task GID.
+		records, err := store.History()
+		if err != nil {
+			return errors.NewGeneralError("failed to load session history", err)
+		}
+		for _, r := range records {
+			if r.TaskGID == args[0] {
+				rec = r
+				break
+			}
+		}
+	}
+	if rec == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("session '%s'", args[0]))
+	}
+
+	out := output.NewJSON(os.Stdout)
+	return out.Print(struct {
+		*session.Record
+		ActiveTime string `json:"active_time"`
+	}{rec, rec.FormatDuration()})
+}
+
+// parseSince reads a --since value: a date, an RFC 3339 time, or an age
+// such as 7d or 12h counted back from now. An empty value is the zero time.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, errors.NewInvalidArgsError("invalid --since, use a date (2024-01-15), a time (RFC 3339) or an age (7d, 12h)")
+}
@@ -51,6 +51,7 @@ Requires --name. Workspace can be specified via --workspace flag or from context
 var (
 	tagListLimit  int
 	tagListOffset string
+	tagListPages  pageFlags
 
 	tagCreateName      string
 	tagCreateWorkspace string
@@ -65,6 +66,7 @@ func init() {
 
 	tagListCmd.Flags().IntVar(&tagListLimit, "limit", 50, "Max results to return")
 	tagListCmd.Flags().StringVar(&tagListOffset, "offset", "", "Pagination offset")
+	addPageFlags(tagListCmd, &tagListPages)
 
 	tagCreateCmd.Flags().StringVar(&tagCreateName, "name", "", "Tag name (required)")
 	tagCreateCmd.Flags().StringVar(&tagCreateWorkspace, "workspace", "", "Workspace GID")
@@ -87,18 +89,10 @@ func runTagList(_ *cobra.Command, _ []string) error {
 
 	opts := api.TagListOptions{
 		Workspace: cfg.Workspace,
-		Limit:     tagListLimit,
-		Offset:    tagListOffset,
 	}
 
 	client := newClient(cfg)
-	result, err := client.ListTags(context.Background(), opts)
-	if err != nil {
-		return err
-	}
-
-	out := output.NewJSON(os.Stdout)
-	return out.Print(result)
+	return printList(context.Background(), &tagListPages, tagListLimit, tagListOffset, api.TagPages(client, opts))
 }
 
 func runTagGet(_ *cobra.Command, args []string) error {
@@ -19,8 +19,11 @@ var taskCmd = &cobra.Command{
 var taskListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List tasks",
-	Long:  "List tasks. Requires --project or workspace from context.",
-	RunE:  runTaskList,
+	Long: `List tasks. Requires --project or workspace from context.
+
+--field filters on custom field values (enum option names, numbers, dates,
+text substrings); an empty value matches tasks where the field is unset.`,
+	RunE: runTaskList,
 }
 
 var taskGetCmd = &cobra.Command{
@@ -45,7 +48,10 @@ Use --parent to create a subtask instead.`,
   asana task create --name "Review PR" --due-on 2024-01-15 --assignee me
 
   # Create a subtask
-  asana task create --name "Write tests" --parent 9876543210`,
+  asana task create --name "Write tests" --parent 9876543210
+
+  # Set custom fields by name
+  asana task create --name "Fix crash" --field "Priority=High" --field "Estimate=3"`,
 	RunE: runTaskCreate,
 }
 
@@ -84,7 +90,7 @@ var taskDeleteCmd = &cobra.Command{
 var taskAssignCmd = &cobra.Command{
 	Use:   "assign <task> <assignee>",
 	Short: "Assign a task to a user",
-	Long:  "Assign a task by GID or name. Uses fuzzy matching for names.",
+	Long:  "Assign a task by GID or name. The assignee can be a GID, email, name or 'me'. Uses fuzzy matching for names.",
 	Args:  cobra.ExactArgs(2),
 	RunE:  runTaskAssign,
 }
@@ -96,6 +102,8 @@ var (
 	taskListLimit     int
 	taskListOffset    string
 	taskListTag       string
+	taskListFields    []string
+	taskListPages     pageFlags
 
 	taskCreateName     string
 	taskCreateNotes    string
@@ -103,11 +111,13 @@ var (
 	taskCreateAssignee string
 	taskCreateDueOn    string
 	taskCreateParent   string
+	taskCreateFields   []string
 
 	taskUpdateName     string
 	taskUpdateNotes    string
 	taskUpdateAssignee string
 	taskUpdateDueOn    string
+	taskUpdateFields   []string
 
 	taskPick bool
 )
@@ -123,31 +133,37 @@ func init() {
 	taskCmd.AddCommand(taskDeleteCmd)
 	taskCmd.AddCommand(taskAssignCmd)
 
-	taskListCmd.Flags().StringVar(&taskListProject, "project", "", "Filter by project GID")
-	taskListCmd.Flags().StringVar(&taskListAssignee, "assignee", "", "Filter by assignee GID or 'me'")
+	taskListCmd.Flags().StringVar(&taskListProject, "project", "", "Filter by project GID or name")
+	taskListCmd.Flags().StringVar(&taskListAssignee, "assignee", "", "Filter by assignee GID, email, name or 'me'")
 	taskListCmd.Flags().StringVar(&taskListCompleted, "completed", "", "Filter by completed status (true/false)")
 	taskListCmd.Flags().IntVar(&taskListLimit, "limit", 50, "Max results to return")
 	taskListCmd.Flags().StringVar(&taskListOffset, "offset", "", "Pagination offset")
-	taskListCmd.Flags().StringVar(&taskListTag, "tag", "", "Filter by tag GID")
+	taskListCmd.Flags().StringVar(&taskListTag, "tag", "", "Filter by tag GID or name")
+	taskListCmd.Flags().StringArrayVar(&taskListFields, "field", nil, "Filter by custom field, e.g. \"Priority=High\" (repeatable)")
+	addPageFlags(taskListCmd, &taskListPages)
 
 	taskCreateCmd.Flags().StringVar(&taskCreateName, "name", "", "Task name (required)")
 	taskCreateCmd.Flags().StringVar(&taskCreateNotes, "notes", "", "Task notes/description")
-	taskCreateCmd.Flags().StringVar(&taskCreateProject, "project", "", "Project GID")
-	taskCreateCmd.Flags().StringVar(&taskCreateAssignee, "assignee", "", "Assignee GID or 'me'")
+	taskCreateCmd.Flags().StringVar(&taskCreateProject, "project", "", "Project GID or name")
+	taskCreateCmd.Flags().StringVar(&taskCreateAssignee, "assignee", "", "Assignee GID, email, name or 'me'")
 	taskCreateCmd.Flags().StringVar(&taskCreateDueOn, "due-on", "", "Due date (YYYY-MM-DD)")
 	taskCreateCmd.Flags().StringVar(&taskCreateParent, "parent", "", "Parent task GID (for subtasks)")
+	taskCreateCmd.Flags().StringArrayVar(&taskCreateFields, "field", nil, "Set custom field, e.g. \"Priority=High\" (repeatable)")
 	_ = taskCreateCmd.MarkFlagRequired("name")
 
 	taskUpdateCmd.Flags().StringVar(&taskUpdateName, "name", "", "New task name")
 	taskUpdateCmd.Flags().StringVar(&taskUpdateNotes, "notes", "", "New task notes")
-	taskUpdateCmd.Flags().StringVar(&taskUpdateAssignee, "assignee", "", "New assignee GID or 'me'")
+	taskUpdateCmd.Flags().StringVar(&taskUpdateAssignee, "assignee", "", "New assignee GID, email, name or 'me'")
 	taskUpdateCmd.Flags().StringVar(&taskUpdateDueOn, "due-on", "", "New due date (YYYY-MM-DD)")
+	taskUpdateCmd.Flags().StringArrayVar(&taskUpdateFields, "field", nil, "Set custom field, e.g. \"Priority=High\"; empty value clears (repeatable)")
 
 	taskGetCmd.Flags().BoolVar(&taskPick, "pick", false, "Show interactive picker if multiple matches")
 	taskUpdateCmd.Flags().BoolVar(&taskPick, "pick", false, "Show interactive picker if multiple matches")
 	taskCompleteCmd.Flags().BoolVar(&taskPick, "pick", false, "Show interactive picker if multiple matches")
 	taskReopenCmd.Flags().BoolVar(&taskPick, "pick", false, "Show interactive picker if multiple matches")
 	taskDeleteCmd.Flags().BoolVar(&taskPick, "pick", false, "Show interactive picker if multiple matches")
+	taskListCmd.Flags().BoolVar(&taskPick, "pick", false, "Show interactive picker if multiple matches")
+	taskCreateCmd.Flags().BoolVar(&taskPick, "pick", false, "Show interactive picker if multiple matches")
 	taskAssignCmd.Flags().BoolVar(&taskPick, "pick", false, "Show interactive picker if multiple matches")
 }
 
@@ -160,12 +176,18 @@ func runTaskList(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
-	opts := api.TaskListOptions{
-		Project:  taskListProject,
-		Assignee: taskListAssignee,
-		Limit:    taskListLimit,
-		Offset:   taskListOffset,
-		Tag:      taskListTag,
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	opts := api.TaskListOptions{}
+	if opts.Project, err = resolveProjectGID(ctx, cfg, client, taskListProject, taskPick); err != nil {
+		return err
+	}
+	if opts.Assignee, err = resolveUserGID(ctx, cfg, client, taskListAssignee, taskPick); err != nil {
+		return err
+	}
+	if opts.Tag, err = resolveTagGID(ctx, cfg, client, taskListTag, taskPick); err != nil {
+		return err
 	}
 
 	if opts.Tag == "" {
@@ -186,14 +208,20 @@ func runTaskList(_ *cobra.Command, _ []string) error {
 		opts.Completed = &completed
 	}
 
-	client := newClient(cfg)
-	result, err := client.ListTasks(context.Background(), opts)
+	fields, err := parseFieldArgs(taskListFields)
 	if err != nil {
 		return err
 	}
 
-	out := newOutput()
-	return out.PrintTaskList(result)
+	if len(fields) > 0 {
+		opts.OptFields = append([]string{"name", "completed"}, api.TaskCustomFieldOptFields...)
+	}
+
+	fetch := api.TaskPages(client, opts)
+	if len(fields) > 0 {
+		fetch = filterTaskPages(fetch, fields)
+	}
+	return printList(ctx, &taskListPages, taskListLimit, taskListOffset, fetch)
 }
 
 func runTaskGet(_ *cobra.Command, args []string) error {
@@ -231,17 +259,25 @@ func runTaskCreate(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	assignee, err := resolveUserGID(ctx, cfg, client, taskCreateAssignee, taskPick)
+	if err != nil {
+		return err
+	}
+
 	req := models.TaskCreateRequest{
 		Name:     taskCreateName,
 		Notes:    taskCreateNotes,
-		Assignee: taskCreateAssignee,
+		Assignee: assignee,
 		DueOn:    taskCreateDueOn,
 		Parent:   taskCreateParent,
 	}
 
-	project := taskCreateProject
-	if project == "" && cfg.Project != "" {
-		project = cfg.Project
+	project, err := resolveProjectFlag(ctx, cfg, client, taskCreateProject, taskPick)
+	if err != nil {
+		return err
 	}
 	if project != "" {
 		req.Projects = []string{project}
@@ -254,13 +290,30 @@ func runTaskCreate(_ *cobra.Command, _ []string) error {
 		req.Workspace = cfg.Workspace
 	}
 
+	fields, err := parseFieldArgs(taskCreateFields)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		if project == "" {
+			return errors.NewInvalidArgsError("--field requires a project to resolve custom fields")
+		}
+		definitions, err := projectCustomFields(ctx, client, project)
+		if err != nil {
+			return err
+		}
+		req.CustomFields, err = resolveCustomFieldValues(definitions, fields, fieldUserResolver(ctx, cfg, client))
+		if err != nil {
+			return err
+		}
+	}
+
 	if cfg.DryRun {
 		out := newOutput()
 		return out.Print(map[string]any{"dry_run": true, "request": req})
 	}
 
-	client := newClient(cfg)
-	task, err := client.CreateTask(context.Background(), req)
+	task, err := client.CreateTask(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -294,12 +347,31 @@ func runTaskUpdate(_ *cobra.Command, args []string) error {
 		req.Notes = &taskUpdateNotes
 	}
 	if taskUpdateAssignee != "" {
-		req.Assignee = &taskUpdateAssignee
+		assignee, err := resolveUserGID(ctx, cfg, client, taskUpdateAssignee, taskPick)
+		if err != nil {
+			return err
+		}
+		req.Assignee = &assignee
 	}
 	if taskUpdateDueOn != "" {
 		req.DueOn = &taskUpdateDueOn
 	}
 
+	fields, err := parseFieldArgs(taskUpdateFields)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		current, err := client.GetTask(ctx, taskGID)
+		if err != nil {
+			return err
+		}
+		req.CustomFields, err = resolveCustomFieldValues(current.CustomFields, fields, fieldUserResolver(ctx, cfg, client))
+		if err != nil {
+			return err
+		}
+	}
+
 	if cfg.DryRun {
 		out := newOutput()
 		return out.Print(map[string]any{"dry_run": true, "gid": taskGID, "request": req})
@@ -467,7 +539,10 @@ func runTaskAssign(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	assignee := args[1]
+	assignee, err := resolveUserGID(ctx, cfg, client, args[1], taskPick)
+	if err != nil {
+		return err
+	}
 	req := models.TaskUpdateRequest{Assignee: &assignee}
 
 	if cfg.DryRun {
@@ -0,0 +1,306 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/output"
+)
+
+var taskAttachmentCmd = &cobra.Command{
+	Use:     "attachment",
+	Aliases: []string{"attach"},
+	Short:   "Manage task attachments",
+}
+
+var taskAttachmentListCmd = &cobra.Command{
+	Use:   "list <task_gid>",
+	Short: "List attachments on a task",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTaskAttachmentList,
+}
+
+var taskAttachmentAddCmd = &cobra.Command{
+	Use:   "add <task_gid> [<file>]",
+	Short: "Attach a file or URL to a task",
+	Long: `Upload a local file to a task, or attach a URL with --url.
+
+Use "-" as the file to read from stdin; --name is then required.`,
+	Example: `  # Attach test output
+  asana task attachment add 1234567890 test-output.log
+
+  # Attach a diff from stdin
+  git diff | asana task attachment add 1234567890 - --name changes.patch
+
+  # Attach a link
+  asana task attachment add 1234567890 --url https://github.com/org/repo/pull/42 --name "PR #42"`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runTaskAttachmentAdd,
+}
+
+var taskAttachmentGetCmd = &cobra.Command{
+	Use:   "get <attachment_gid>",
+	Short: "Get attachment details",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTaskAttachmentGet,
+}
+
+var taskAttachmentDownloadCmd = &cobra.Command{
+	Use:   "download <attachment_gid>",
+	Short: "Download an attachment",
+	Long: `Download an attachment to the current directory using its name, or to
+--output. Use --output - to write to stdout.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskAttachmentDownload,
+}
+
+var taskAttachmentRmCmd = &cobra.Command{
+	Use:   "rm <attachment_gid>",
+	Short: "Delete an attachment",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTaskAttachmentRm,
+}
+
+var (
+	attachmentListLimit  int
+	attachmentListOffset string
+	attachmentListPages  pageFlags
+
+	attachmentAddURL  string
+	attachmentAddName string
+
+	attachmentDownloadOutput string
+	attachmentDownloadForce  bool
+)
+
+func init() {
+	taskCmd.AddCommand(taskAttachmentCmd)
+	taskAttachmentCmd.AddCommand(taskAttachmentListCmd)
+	taskAttachmentCmd.AddCommand(taskAttachmentAddCmd)
+	taskAttachmentCmd.AddCommand(taskAttachmentGetCmd)
+	taskAttachmentCmd.AddCommand(taskAttachmentDownloadCmd)
+	taskAttachmentCmd.AddCommand(taskAttachmentRmCmd)
+
+	taskAttachmentListCmd.Flags().IntVar(&attachmentListLimit, "limit", 50, "Max results to return")
+	taskAttachmentListCmd.Flags().StringVar(&attachmentListOffset, "offset", "", "Pagination offset")
+	addPageFlags(taskAttachmentListCmd, &attachmentListPages)
+
+	taskAttachmentAddCmd.Flags().StringVar(&attachmentAddURL, "url", "", "Attach a URL instead of a file")
+	taskAttachmentAddCmd.Flags().StringVar(&attachmentAddName, "name", "", "Attachment name (defaults to file name or URL)")
+
+	taskAttachmentDownloadCmd.Flags().StringVarP(&attachmentDownloadOutput, "output", "o", "", "Output path, or - for stdout")
+	taskAttachmentDownloadCmd.Flags().BoolVar(&attachmentDownloadForce, "force", false, "Overwrite an existing file")
+}
+
+func runTaskAttachmentList(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	client := newClient(cfg)
+	opts := api.AttachmentListOptions{Parent: args[0]}
+	return printList(context.Background(), &attachmentListPages, attachmentListLimit, attachmentListOffset, api.AttachmentPages(client, opts))
+}
+
+func runTaskAttachmentAdd(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	taskGID := args[0]
+	hasFile := len(args) == 2
+
+	if hasFile == (attachmentAddURL != "") {
+		return errors.NewInvalidArgsError("provide either a file or --url")
+	}
+
+	if attachmentAddURL != "" {
+		req := api.AttachmentURLRequest{Parent: taskGID, URL: attachmentAddURL, Name: attachmentAddName}
+		if cfg.DryRun {
+			out := output.NewJSON(os.Stdout)
+			return out.Print(map[string]any{"dry_run": true, "task_gid": taskGID, "url": req.URL, "name": req.Name})
+		}
+
+		client := newClient(cfg)
+		attachment, err := client.AttachURL(context.Background(), req)
+		if err != nil {
+			return err
+		}
+
+		out := output.NewJSON(os.Stdout)
+		return out.Print(attachment)
+	}
+
+	path := args[1]
+	name := attachmentAddName
+
+	var content io.Reader
+	var size int64 = -1
+	if path == "-" {
+		if name == "" {
+			return errors.NewInvalidArgsError("--name is required when reading from stdin")
+		}
+		content = os.Stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return errors.NewInvalidArgsError("cannot open file: " + err.Error())
+		}
+		defer func() { _ = f.Close() }()
+
+		info, err := f.Stat()
+		if err != nil {
+			return errors.NewGeneralError("failed to stat file", err)
+		}
+		if info.IsDir() {
+			return errors.NewInvalidArgsError(path + " is a directory")
+		}
+		size = info.Size()
+		content = f
+
+		if name == "" {
+			name = filepath.Base(path)
+		}
+	}
+
+	if cfg.DryRun {
+		result := map[string]any{"dry_run": true, "task_gid": taskGID, "file": path, "name": name}
+		if size >= 0 {
+			result["size"] = size
+		}
+		out := output.NewJSON(os.Stdout)
+		return out.Print(result)
+	}
+
+	client := newClient(cfg)
+	attachment, err := client.UploadAttachment(context.Background(), api.AttachmentUploadRequest{
+		Parent:  taskGID,
+		Name:    name,
+		Content: content,
+	})
+	if err != nil {
+		return err
+	}
+
+	out := output.NewJSON(os.Stdout)
+	return out.Print(attachment)
+}
+
+func runTaskAttachmentGet(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	client := newClient(cfg)
+	attachment, err := client.GetAttachment(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+
+	out := newOutput()
+	return out.Print(attachment)
+}
+
+func runTaskAttachmentDownload(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	attachment, err := client.GetAttachment(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	if attachment.DownloadURL == "" {
+		return errors.NewGeneralError("attachment has no download URL (external attachments can't be downloaded)", nil)
+	}
+
+	if attachmentDownloadOutput == "-" {
+		_, err := client.DownloadAttachment(ctx, attachment.DownloadURL, os.Stdout)
+		return err
+	}
+
+	path := attachmentDownloadOutput
+	if path == "" {
+		path = filepath.Base(attachment.Name)
+		if path == "." || path == string(filepath.Separator) {
+			path = attachment.GID
+		}
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !attachmentDownloadForce {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		if os.IsExist(err) {
+			return errors.NewInvalidArgsError(path + " already exists, use --force to overwrite")
+		}
+		return errors.NewGeneralError("failed to create file", err)
+	}
+
+	n, err := client.DownloadAttachment(ctx, attachment.DownloadURL, f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = errors.NewGeneralError("failed to write file", closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+
+	out := output.NewJSON(os.Stdout)
+	return out.Print(map[string]any{
+		"gid":   attachment.GID,
+		"name":  attachment.Name,
+		"path":  path,
+		"bytes": n,
+	})
+}
+
+func runTaskAttachmentRm(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	if cfg.DryRun {
+		out := output.NewJSON(os.Stdout)
+		return out.Print(map[string]any{"dry_run": true, "action": "delete", "gid": args[0]})
+	}
+
+	client := newClient(cfg)
+	if err := client.DeleteAttachment(context.Background(), args[0]); err != nil {
+		return err
+	}
+
+	out := output.NewJSON(os.Stdout)
+	return out.Print(map[string]any{"deleted": true, "gid": args[0]})
+}
@@ -0,0 +1,356 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/models"
+	"github.com/whoaa512/asana-cli/internal/output"
+)
+
+var taskBulkCmd = &cobra.Command{
+	Use:   "bulk <action>",
+	Short: "Apply an action to many tasks",
+	Long: `Apply an action to many tasks at once.
+
+Actions: complete, reopen, move, tag, untag, assign.
+
+Task GIDs are read from stdin (one per line, brief output, or NDJSON from
+'task list --format ndjson'), or selected with --where. Mutations are sent
+through the batch API with bounded concurrency. Output is a per-task result
+array; the exit code is non-zero if any task fails.`,
+	Example: `  # Complete every task in a list
+  asana task list --project 123 --format ndjson | asana task bulk complete
+
+  # Move incomplete tasks assigned to me into a section
+  asana task bulk move --section 456 --where "project=123,assignee=me,completed=false"
+
+  # Preview tagging tasks whose name contains "flaky"
+  asana task bulk tag --tag 789 --where "project=123,name=flaky" --dry-run`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: bulkActions,
+	RunE:      runTaskBulk,
+}
+
+var bulkActions = []string{"complete", "reopen", "move", "tag", "untag", "assign"}
+
+var (
+	taskBulkWhere       string
+	taskBulkSection     string
+	taskBulkTag         string
+	taskBulkAssignee    string
+	taskBulkConcurrency int
+)
+
+func init() {
+	taskCmd.AddCommand(taskBulkCmd)
+
+	taskBulkCmd.Flags().StringVar(&taskBulkWhere, "where", "", "Select tasks by filter: project=,tag=,assignee=,completed=,name= (comma-separated; project, tag and assignee take a GID or name)")
+	taskBulkCmd.Flags().StringVar(&taskBulkSection, "section", "", "Section GID or name in the context project (for move)")
+	taskBulkCmd.Flags().StringVar(&taskBulkTag, "tag", "", "Tag GID or name (for tag, untag)")
+	taskBulkCmd.Flags().StringVar(&taskBulkAssignee, "assignee", "", "Assignee GID, email, name or 'me' (for assign)")
+	taskBulkCmd.Flags().IntVar(&taskBulkConcurrency, "concurrency", 4, "Max batch requests in flight")
+}
+
+func runTaskBulk(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	action := args[0]
+	if err := validateBulkFlags(action); err != nil {
+		return err
+	}
+
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	if err := resolveBulkFlags(ctx, cfg, client, action); err != nil {
+		return err
+	}
+
+	var taskGIDs []string
+	if taskBulkWhere != "" {
+		taskGIDs, err = selectTasksWhere(ctx, cfg, client, taskBulkWhere)
+	} else {
+		if isTerminal(os.Stdin) {
+			return errors.NewInvalidArgsError("no tasks given: pipe task GIDs on stdin or use --where")
+		}
+		taskGIDs, err = readTaskGIDs(os.Stdin)
+	}
+	if err != nil {
+		return err
+	}
+
+	return applyBulk(ctx, cfg, client, os.Stdout, action, taskGIDs, taskBulkConcurrency)
+}
+
+// applyBulk runs action on every task, or prints the plan on a dry run, and
+// writes the per-task results to w. A partly failed run returns a
+// reportedError, as the failures are already in the output.
+func applyBulk(ctx context.Context, cfg *config.Config, client api.Client, w io.Writer, action string, taskGIDs []string, concurrency int) error {
+	actions := buildBulkActions(cfg, action, taskGIDs)
+
+	if cfg.DryRun {
+		plan := make([]map[string]any, len(actions))
+		for i, a := range actions {
+			plan[i] = map[string]any{"task_gid": a.TaskGID, "request": a.Action}
+		}
+		out := output.NewJSON(w)
+		return out.Print(map[string]any{
+			"dry_run": true,
+			"action":  action,
+			"count":   len(taskGIDs),
+			"tasks":   taskGIDs,
+			"plan":    plan,
+		})
+	}
+
+	results, failed, err := applyTaskActions(ctx, client, actions, concurrency)
+	if err != nil {
+		return err
+	}
+
+	out := output.NewJSON(w)
+	if err := out.Print(map[string]any{
+		"action":    action,
+		"results":   results,
+		"succeeded": len(results) - failed,
+		"failed":    failed,
+	}); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return &reportedError{exitCode: errors.ExitGeneral}
+	}
+	return nil
+}
+
+func validateBulkFlags(action string) error {
+	switch action {
+	case "complete", "reopen":
+	case "move":
+		if taskBulkSection == "" {
+			return errors.NewInvalidArgsError("move requires --section")
+		}
+	case "tag", "untag":
+		if taskBulkTag == "" {
+			return errors.NewInvalidArgsError(action + " requires --tag")
+		}
+	case "assign":
+		if taskBulkAssignee == "" {
+			return errors.NewInvalidArgsError("assign requires --assignee")
+		}
+	default:
+		return errors.NewInvalidArgsError(fmt.Sprintf("unknown action %q, must be one of: %s", action, strings.Join(bulkActions, ", ")))
+	}
+	return nil
+}
+
+// resolveBulkFlags replaces names in the flag the action uses with GIDs.
+func resolveBulkFlags(ctx context.Context, cfg *config.Config, client api.Client, action string) error {
+	var err error
+	switch action {
+	case "move":
+		projects := func(context.Context) ([]models.AsanaResource, error) {
+			if cfg.Project == "" {
+				return nil, nil
+			}
+			return []models.AsanaResource{{GID: cfg.Project}}, nil
+		}
+		taskBulkSection, err = resolveSectionGID(ctx, cfg, client, projects, taskBulkSection, false)
+	case "tag", "untag":
+		taskBulkTag, err = resolveTagGID(ctx, cfg, client, taskBulkTag, false)
+	case "assign":
+		taskBulkAssignee, err = resolveUserGID(ctx, cfg, client, taskBulkAssignee, false)
+	}
+	return err
+}
+
+// buildBulkActions mirrors the single-task commands, including moving
+// completed and reopened tasks to the configured done/in_progress sections.
+func buildBulkActions(cfg *config.Config, action string, taskGIDs []string) []taskAction {
+	var actions []taskAction
+	add := func(gid string, a api.BatchAction) {
+		actions = append(actions, taskAction{TaskGID: gid, Action: a})
+	}
+
+	for _, gid := range taskGIDs {
+		switch action {
+		case "complete", "reopen":
+			completed := action == "complete"
+			add(gid, api.UpdateTaskAction(gid, models.TaskUpdateRequest{Completed: &completed}))
+
+			section := "in_progress"
+			if completed {
+				section = "done"
+			}
+			if cfg.Sections != nil && cfg.Sections[section] != "" {
+				add(gid, api.AddTaskToSectionAction(cfg.Sections[section], gid))
+			}
+		case "move":
+			add(gid, api.AddTaskToSectionAction(taskBulkSection, gid))
+		case "tag":
+			add(gid, api.AddTagAction(gid, taskBulkTag))
+		case "untag":
+			add(gid, api.RemoveTagAction(gid, taskBulkTag))
+		case "assign":
+			assignee := taskBulkAssignee
+			add(gid, api.UpdateTaskAction(gid, models.TaskUpdateRequest{Assignee: &assignee}))
+		}
+	}
+	return actions
+}
+
+// readTaskGIDs accepts one GID per line, brief output ("<gid>  <name>"), or
+// NDJSON objects with a "gid" field. Duplicates are dropped.
+func readTaskGIDs(r io.Reader) ([]string, error) {
+	var gids []string
+	seen := map[string]bool{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var gid string
+		if strings.HasPrefix(text, "{") {
+			var obj struct {
+				GID string `json:"gid"`
+			}
+			if err := json.Unmarshal([]byte(text), &obj); err != nil {
+				return nil, errors.NewInvalidArgsError(fmt.Sprintf("line %d: invalid JSON: %v", line, err))
+			}
+			gid = obj.GID
+		} else {
+			gid = strings.Fields(text)[0]
+		}
+
+		if !gidRegex.MatchString(gid) {
+			return nil, errors.NewInvalidArgsError(fmt.Sprintf("line %d: %q is not a task GID", line, gid))
+		}
+		if !seen[gid] {
+			seen[gid] = true
+			gids = append(gids, gid)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.NewGeneralError("failed to read stdin", err)
+	}
+
+	if len(gids) == 0 {
+		return nil, errors.NewInvalidArgsError("no task GIDs read from stdin")
+	}
+	return gids, nil
+}
+
+type taskFilter struct {
+	opts api.TaskListOptions
+	name string
+}
+
+func parseWhere(where string) (taskFilter, error) {
+	var f taskFilter
+	for _, clause := range strings.Split(where, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(clause, "=")
+		if !ok {
+			return f, errors.NewInvalidArgsError(fmt.Sprintf("invalid --where clause %q, expected key=value", clause))
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "project":
+			f.opts.Project = value
+		case "tag":
+			f.opts.Tag = value
+		case "assignee":
+			f.opts.Assignee = value
+		case "completed":
+			if value != "true" && value != "false" {
+				return f, errors.NewInvalidArgsError("--where completed must be true or false")
+			}
+			completed := value == "true"
+			f.opts.Completed = &completed
+		case "name":
+			f.name = strings.ToLower(value)
+		default:
+			return f, errors.NewInvalidArgsError(fmt.Sprintf("unknown --where key %q", key))
+		}
+	}
+	return f, nil
+}
+
+func selectTasksWhere(ctx context.Context, cfg *config.Config, client api.Client, where string) ([]string, error) {
+	f, err := parseWhere(where)
+	if err != nil {
+		return nil, err
+	}
+	if f.opts.Project, err = resolveProjectGID(ctx, cfg, client, f.opts.Project, false); err != nil {
+		return nil, err
+	}
+	if f.opts.Tag, err = resolveTagGID(ctx, cfg, client, f.opts.Tag, false); err != nil {
+		return nil, err
+	}
+	if f.opts.Assignee, err = resolveUserGID(ctx, cfg, client, f.opts.Assignee, false); err != nil {
+		return nil, err
+	}
+
+	if f.opts.Tag == "" && f.opts.Project == "" {
+		f.opts.Project = cfg.Project
+		if f.opts.Project == "" {
+			if cfg.Workspace == "" {
+				return nil, errors.NewGeneralError("no project, tag, or workspace specified", nil)
+			}
+			f.opts.Workspace = cfg.Workspace
+		}
+	}
+	f.opts.OptFields = []string{"name", "completed"}
+
+	result, err := api.Paginate(ctx, api.PageOptions{}, api.TaskPages(client, f.opts))
+	if err != nil {
+		return nil, err
+	}
+
+	var gids []string
+	for _, task := range result.Data {
+		if f.name != "" && !strings.Contains(strings.ToLower(task.Name), f.name) {
+			continue
+		}
+		gids = append(gids, task.GID)
+	}
+
+	if len(gids) == 0 {
+		return nil, errors.NewGeneralError("no tasks match --where filter", nil)
+	}
+	return gids, nil
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/errors"
+)
+
+func TestReadTaskGIDs(t *testing.T) {
+	input := `111
+222  Some task name
+{"gid":"333","name":"From ndjson"}
+
+111
+`
+	gids, err := readTaskGIDs(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("readTaskGIDs() error = %v", err)
+	}
+
+	want := []string{"111", "222", "333"}
+	if strings.Join(gids, ",") != strings.Join(want, ",") {
+		t.Errorf("gids = %v, want %v", gids, want)
+	}
+}
+
+func TestReadTaskGIDsInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"not a gid", "abc\n"},
+		{"bad json", "{nope\n"},
+		{"empty", "\n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := readTaskGIDs(strings.NewReader(tt.input)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestParseWhere(t *testing.T) {
+	f, err := parseWhere("project=123, assignee=me,completed=false,name=Flaky")
+	if err != nil {
+		t.Fatalf("parseWhere() error = %v", err)
+	}
+
+	if f.opts.Project != "123" || f.opts.Assignee != "me" {
+		t.Errorf("opts = %+v", f.opts)
+	}
+	if f.opts.Completed == nil || *f.opts.Completed {
+		t.Errorf("Completed = %v, want false", f.opts.Completed)
+	}
+	if f.name != "flaky" {
+		t.Errorf("name = %q, want %q", f.name, "flaky")
+	}
+
+	if _, err := parseWhere("color=red"); err == nil {
+		t.Error("expected error for unknown key")
+	}
+	if _, err := parseWhere("project"); err == nil {
+		t.Error("expected error for missing value")
+	}
+}
+
+func TestSelectTasksWhereResolvesNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/9/projects":
+			_, _ = w.Write([]byte(`{"data": [{"gid": "123", "name": "Q3 Launch"}, {"gid": "456", "name": "Hiring"}]}`))
+		case "/projects/123/tasks":
+			_, _ = w.Write([]byte(`{"data": [{"gid": "1", "name": "Fix flaky test"}, {"gid": "2", "name": "Ship it"}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second, Workspace: "9"}
+	client := api.NewHTTPClient(cfg, api.WithBaseURL(server.URL))
+
+	gids, err := selectTasksWhere(context.Background(), cfg, client, "project=Q3 Launch,name=flaky")
+	if err != nil {
+		t.Fatalf("selectTasksWhere() error = %v", err)
+	}
+	if !reflect.DeepEqual(gids, []string{"1"}) {
+		t.Errorf("gids = %v, want [1]", gids)
+	}
+}
+
+func TestApplyBulkReportsPerTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Data struct {
+				Actions []api.BatchAction `json:"actions"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		var results []string
+		for _, a := range req.Data.Actions {
+			data, _ := json.Marshal(a.Data)
+			switch {
+			case a.RelativePath == "/tasks/3":
+				results = append(results, `{"status_code": 404, "body": {"errors": [{"message": "Unknown object: 3"}]}}`)
+			case strings.HasPrefix(a.RelativePath, "/sections/") && strings.Contains(string(data), `"2"`):
+				results = append(results, `{"status_code": 403, "body": {"errors": [{"message": "Forbidden"}]}}`)
+			default:
+				results = append(results, `{"status_code": 200, "body": {"data": {}}}`)
+			}
+		}
+		_, _ = w.Write([]byte(`{"data": [` + strings.Join(results, ",") + `]}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second, Sections: map[string]string{"done": "77"}}
+	client := api.NewHTTPClient(cfg, api.WithBaseURL(server.URL))
+
+	var buf bytes.Buffer
+	err := applyBulk(context.Background(), cfg, client, &buf, "complete", []string{"1", "2", "3"}, 2)
+	var reported *reportedError
+	if !stderrors.As(err, &reported) || reported.exitCode != errors.ExitGeneral {
+		t.Fatalf("applyBulk() error = %v, want a reported failure", err)
+	}
+
+	var got struct {
+		Results   []taskActionResult `json:"results"`
+		Succeeded int                `json:"succeeded"`
+		Failed    int                `json:"failed"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output %q: %v", buf.String(), err)
+	}
+	if got.Succeeded != 1 || got.Failed != 2 || len(got.Results) != 3 {
+		t.Fatalf("output = %+v, want 1 succeeded and 2 failed out of 3 tasks", got)
+	}
+	for i, want := range []struct {
+		gid     string
+		success bool
+		msg     string
+	}{{"1", true, ""}, {"2", false, "Forbidden"}, {"3", false, "not found"}} {
+		r := got.Results[i]
+		if r.TaskGID != want.gid || r.Success != want.success || !strings.Contains(r.Error, want.msg) {
+			t.Errorf("results[%d] = %+v, want task %s success=%v error containing %q", i, r, want.gid, want.success, want.msg)
+		}
+		if !r.Success && r.Code == "" {
+			t.Errorf("results[%d] has no error code", i)
+		}
+	}
+}
+
+func TestApplyBulkDryRun(t *testing.T) {
+	cfg := &config.Config{DryRun: true, Sections: map[string]string{"done": "77"}}
+
+	var buf bytes.Buffer
+	if err := applyBulk(context.Background(), cfg, nil, &buf, "complete", []string{"1", "2"}, 1); err != nil {
+		t.Fatalf("applyBulk() error = %v", err)
+	}
+
+	var got struct {
+		DryRun bool `json:"dry_run"`
+		Count  int  `json:"count"`
+		Plan   []struct {
+			TaskGID string          `json:"task_gid"`
+			Request api.BatchAction `json:"request"`
+		} `json:"plan"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output %q: %v", buf.String(), err)
+	}
+	if !got.DryRun || got.Count != 2 || len(got.Plan) != 4 {
+		t.Fatalf("output = %+v, want a dry run planning 4 actions for 2 tasks", got)
+	}
+	if p := got.Plan[1]; p.TaskGID != "1" || p.Request.RelativePath != "/sections/77/addTask" {
+		t.Errorf("plan[1] = %+v, want task 1 moved to the done section", p)
+	}
+}
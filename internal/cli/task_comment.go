@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/whoaa512/asana-cli/internal/api"
 	"github.com/whoaa512/asana-cli/internal/models"
 	"github.com/whoaa512/asana-cli/internal/output"
 )
@@ -32,6 +33,7 @@ var taskCommentAddCmd = &cobra.Command{
 var (
 	commentListLimit  int
 	commentListOffset string
+	commentListPages  pageFlags
 	commentAddText    string
 )
 
@@ -42,6 +44,7 @@ func init() {
 
 	taskCommentListCmd.Flags().IntVar(&commentListLimit, "limit", 50, "Max results to return")
 	taskCommentListCmd.Flags().StringVar(&commentListOffset, "offset", "", "Pagination offset")
+	addPageFlags(taskCommentListCmd, &commentListPages)
 
 	taskCommentAddCmd.Flags().StringVar(&commentAddText, "text", "", "Comment text (required)")
 	_ = taskCommentAddCmd.MarkFlagRequired("text")
@@ -57,25 +60,40 @@ func runTaskCommentList(_ *cobra.Command, args []string) error {
 	}
 
 	client := newClient(cfg)
-	result, err := client.ListStories(context.Background(), args[0], commentListLimit, commentListOffset)
-	if err != nil {
-		return err
-	}
+	stories := api.StoryPages(client, args[0])
+	userName := userNamer(cfg, client)
+	comments := func(ctx context.Context, offset string, limit int) (*models.ListResponse[models.Story], error) {
+		result, err := stories(ctx, offset, limit)
+		if err != nil {
+			return nil, err
+		}
 
-	var comments []models.Story
-	for _, story := range result.Data {
-		if story.Type == "comment" {
-			comments = append(comments, story)
+		var comments []models.Story
+		for _, story := range result.Data {
+			if story.Type == "comment" {
+				nameAuthor(ctx, &story, userName)
+				comments = append(comments, story)
+			}
 		}
-	}
 
-	filteredResult := &models.ListResponse[models.Story]{
-		Data:     comments,
-		NextPage: result.NextPage,
+		return &models.ListResponse[models.Story]{
+			Data:     comments,
+			NextPage: result.NextPage,
+		}, nil
 	}
 
-	out := output.NewJSON(os.Stdout)
-	return out.Print(filteredResult)
+	return printList(context.Background(), &commentListPages, commentListLimit, commentListOffset, comments)
+}
+
+// nameAuthor fills in the author's name from the member directory when the
+// API returned only their GID.
+func nameAuthor(ctx context.Context, story *models.Story, userName func(ctx context.Context, gid string) string) {
+	if userName == nil || story.CreatedBy == nil || story.CreatedBy.Name != "" || story.CreatedBy.GID == "" {
+		return
+	}
+	author := *story.CreatedBy
+	author.Name = userName(ctx, author.GID)
+	story.CreatedBy = &author
 }
 
 func runTaskCommentAdd(_ *cobra.Command, args []string) error {
@@ -2,10 +2,12 @@ package cli
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 
+	"github.com/whoaa512/asana-cli/internal/errors"
 	"github.com/whoaa512/asana-cli/internal/output"
 )
 
@@ -17,8 +19,14 @@ var taskDepCmd = &cobra.Command{
 var taskDepAddCmd = &cobra.Command{
 	Use:   "add <task_gid> <depends_on_gid>",
 	Short: "Add a dependency (mark task as blocked by depends_on)",
-	Args:  cobra.ExactArgs(2),
-	RunE:  runTaskDepAdd,
+	Long: `Add a dependency (mark task as blocked by depends_on).
+
+Before adding, the dependencies of depends_on are walked transitively,
+across projects. If task is among them the new dependency would create a
+deadlock, so it is refused with exit code 2 and the cycle in the error's
+details.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runTaskDepAdd,
 }
 
 var taskDepListCmd = &cobra.Command{
@@ -35,11 +43,15 @@ var taskDepRmCmd = &cobra.Command{
 	RunE:  runTaskDepRm,
 }
 
+var taskDepAddSkipCheck bool
+
 func init() {
 	taskCmd.AddCommand(taskDepCmd)
 	taskDepCmd.AddCommand(taskDepAddCmd)
 	taskDepCmd.AddCommand(taskDepListCmd)
 	taskDepCmd.AddCommand(taskDepRmCmd)
+
+	taskDepAddCmd.Flags().BoolVar(&taskDepAddSkipCheck, "skip-check", false, "Add without checking for dependency cycles")
 }
 
 func runTaskDepAdd(_ *cobra.Command, args []string) error {
@@ -54,6 +66,23 @@ func runTaskDepAdd(_ *cobra.Command, args []string) error {
 	taskGID := args[0]
 	dependsOnGID := args[1]
 
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	if !taskDepAddSkipCheck {
+		cycle, err := findDependencyCycle(ctx, client, taskGID, dependsOnGID)
+		if err != nil {
+			return err
+		}
+		if cycle != nil {
+			msg := fmt.Sprintf("task %s already depends on %s, adding this dependency would create a cycle", dependsOnGID, taskGID)
+			if taskGID == dependsOnGID {
+				msg = "a task cannot depend on itself"
+			}
+			return errors.NewDependencyCycleError(msg, cycle)
+		}
+	}
+
 	if cfg.DryRun {
 		out := output.NewJSON(os.Stdout)
 		return out.Print(map[string]any{
@@ -63,8 +92,7 @@ func runTaskDepAdd(_ *cobra.Command, args []string) error {
 		})
 	}
 
-	client := newClient(cfg)
-	if err := client.AddDependency(context.Background(), taskGID, dependsOnGID); err != nil {
+	if err := client.AddDependency(ctx, taskGID, dependsOnGID); err != nil {
 		return err
 	}
 
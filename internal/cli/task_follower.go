@@ -15,14 +15,14 @@ var taskFollowerCmd = &cobra.Command{
 }
 
 var taskFollowerAddCmd = &cobra.Command{
-	Use:   "add <task_gid> <follower_gid>",
+	Use:   "add <task> <user>",
 	Short: "Add a follower to a task",
 	Args:  cobra.ExactArgs(2),
 	RunE:  runTaskFollowerAdd,
 }
 
 var taskFollowerRmCmd = &cobra.Command{
-	Use:   "rm <task_gid> <follower_gid>",
+	Use:   "rm <task> <user>",
 	Short: "Remove a follower from a task",
 	Args:  cobra.ExactArgs(2),
 	RunE:  runTaskFollowerRm,
@@ -32,6 +32,9 @@ func init() {
 	taskCmd.AddCommand(taskFollowerCmd)
 	taskFollowerCmd.AddCommand(taskFollowerAddCmd)
 	taskFollowerCmd.AddCommand(taskFollowerRmCmd)
+
+	taskFollowerAddCmd.Flags().BoolVar(&taskPick, "pick", false, "Show interactive picker if multiple matches")
+	taskFollowerRmCmd.Flags().BoolVar(&taskPick, "pick", false, "Show interactive picker if multiple matches")
 }
 
 func runTaskFollowerAdd(_ *cobra.Command, args []string) error {
@@ -43,8 +46,17 @@ func runTaskFollowerAdd(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	taskGID := args[0]
-	followerGID := args[1]
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	taskGID, err := resolveTaskGID(ctx, cfg, client, args[0], taskPick)
+	if err != nil {
+		return err
+	}
+	followerGID, err := resolveUserGID(ctx, cfg, client, args[1], taskPick)
+	if err != nil {
+		return err
+	}
 
 	if cfg.DryRun {
 		out := output.NewJSON(os.Stdout)
@@ -55,8 +67,7 @@ func runTaskFollowerAdd(_ *cobra.Command, args []string) error {
 		})
 	}
 
-	client := newClient(cfg)
-	task, err := client.AddFollowers(context.Background(), taskGID, []string{followerGID})
+	task, err := client.AddFollowers(ctx, taskGID, []string{followerGID})
 	if err != nil {
 		return err
 	}
@@ -74,8 +85,17 @@ func runTaskFollowerRm(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	taskGID := args[0]
-	followerGID := args[1]
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	taskGID, err := resolveTaskGID(ctx, cfg, client, args[0], taskPick)
+	if err != nil {
+		return err
+	}
+	followerGID, err := resolveUserGID(ctx, cfg, client, args[1], taskPick)
+	if err != nil {
+		return err
+	}
 
 	if cfg.DryRun {
 		out := output.NewJSON(os.Stdout)
@@ -87,8 +107,7 @@ func runTaskFollowerRm(_ *cobra.Command, args []string) error {
 		})
 	}
 
-	client := newClient(cfg)
-	task, err := client.RemoveFollower(context.Background(), taskGID, followerGID)
+	task, err := client.RemoveFollower(ctx, taskGID, followerGID)
 	if err != nil {
 		return err
 	}
@@ -6,15 +6,24 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
 	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/models"
 	"github.com/whoaa512/asana-cli/internal/output"
 )
 
 var taskMoveCmd = &cobra.Command{
-	Use:   "move <task-gid>",
+	Use:   "move <task>",
 	Short: "Move task to a section",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runTaskMove,
+	Long: `Move a task to a section. The task and section can be GIDs or names.
+
+Section names are looked up in --project, the context project, or failing
+those the task's own projects. Section aliases from .asana.json, such as
+in_progress, also work.`,
+	Example: `  asana task move "login bug" --section "In Review"`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runTaskMove,
 }
 
 var taskStartCmd = &cobra.Command{
@@ -40,6 +49,7 @@ var taskPlanCmd = &cobra.Command{
 
 var (
 	taskMoveSection string
+	taskMoveProject string
 )
 
 func init() {
@@ -48,7 +58,9 @@ func init() {
 	taskCmd.AddCommand(taskBlockCmd)
 	taskCmd.AddCommand(taskPlanCmd)
 
-	taskMoveCmd.Flags().StringVar(&taskMoveSection, "section", "", "Section GID (required)")
+	taskMoveCmd.Flags().StringVar(&taskMoveSection, "section", "", "Section GID or name (required)")
+	taskMoveCmd.Flags().StringVar(&taskMoveProject, "project", "", "Project GID or name to find the section in")
+	taskMoveCmd.Flags().BoolVar(&taskPick, "pick", false, "Show interactive picker if multiple matches")
 	if err := taskMoveCmd.MarkFlagRequired("section"); err != nil {
 		panic(err)
 	}
@@ -63,20 +75,46 @@ func runTaskMove(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	taskGID := args[0]
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	taskGID, err := resolveTaskGID(ctx, cfg, client, args[0], taskPick)
+	if err != nil {
+		return err
+	}
+
+	projects := func(ctx context.Context) ([]models.AsanaResource, error) {
+		return sectionProjects(ctx, cfg, client, taskGID, taskMoveProject)
+	}
+	sectionGID, err := resolveSectionGID(ctx, cfg, client, projects, taskMoveSection, taskPick)
+	if err != nil {
+		return err
+	}
 
 	if cfg.DryRun {
 		out := output.NewJSON(os.Stdout)
-		return out.Print(map[string]any{"dry_run": true, "task": taskGID, "section": taskMoveSection})
+		return out.Print(map[string]any{"dry_run": true, "task": taskGID, "section": sectionGID})
 	}
 
-	client := newClient(cfg)
-	if err := client.AddTaskToSection(context.Background(), taskMoveSection, taskGID); err != nil {
+	if err := client.AddTaskToSection(ctx, sectionGID, taskGID); err != nil {
 		return err
 	}
 
 	out := output.NewJSON(os.Stdout)
-	return out.Print(map[string]any{"success": true, "task": taskGID, "section": taskMoveSection})
+	return out.Print(map[string]any{"success": true, "task": taskGID, "section": sectionGID})
+}
+
+// sectionProjects returns the projects to look a section name up in: the
+// --project flag, the context project, or the projects the task is in.
+func sectionProjects(ctx context.Context, cfg *config.Config, client api.Client, taskGID, projectFlag string) ([]models.AsanaResource, error) {
+	project, err := resolveProjectFlag(ctx, cfg, client, projectFlag, taskPick)
+	if err != nil {
+		return nil, err
+	}
+	if project != "" {
+		return []models.AsanaResource{{GID: project}}, nil
+	}
+	return client.ListTaskProjects(ctx, taskGID)
 }
 
 func runTaskStart(_ *cobra.Command, args []string) error {
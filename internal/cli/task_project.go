@@ -15,14 +15,14 @@ var taskProjectCmd = &cobra.Command{
 }
 
 var taskProjectAddCmd = &cobra.Command{
-	Use:   "add <task_gid> <project_gid>",
+	Use:   "add <task> <project>",
 	Short: "Add a task to a project",
 	Args:  cobra.ExactArgs(2),
 	RunE:  runTaskProjectAdd,
 }
 
 var taskProjectRmCmd = &cobra.Command{
-	Use:   "rm <task_gid> <project_gid>",
+	Use:   "rm <task> <project>",
 	Short: "Remove a task from a project",
 	Args:  cobra.ExactArgs(2),
 	RunE:  runTaskProjectRm,
@@ -51,8 +51,17 @@ func runTaskProjectAdd(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	taskGID := args[0]
-	projectGID := args[1]
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	taskGID, err := resolveTaskGID(ctx, cfg, client, args[0], taskPick)
+	if err != nil {
+		return err
+	}
+	projectGID, err := resolveProjectGID(ctx, cfg, client, args[1], taskPick)
+	if err != nil {
+		return err
+	}
 
 	if cfg.DryRun {
 		out := output.NewJSON(os.Stdout)
@@ -64,8 +73,7 @@ func runTaskProjectAdd(_ *cobra.Command, args []string) error {
 		})
 	}
 
-	client := newClient(cfg)
-	task, err := client.AddToProject(context.Background(), taskGID, projectGID)
+	task, err := client.AddToProject(ctx, taskGID, projectGID)
 	if err != nil {
 		return err
 	}
@@ -83,8 +91,17 @@ func runTaskProjectRm(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	taskGID := args[0]
-	projectGID := args[1]
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	taskGID, err := resolveTaskGID(ctx, cfg, client, args[0], taskPick)
+	if err != nil {
+		return err
+	}
+	projectGID, err := resolveProjectGID(ctx, cfg, client, args[1], taskPick)
+	if err != nil {
+		return err
+	}
 
 	if cfg.DryRun {
 		out := output.NewJSON(os.Stdout)
@@ -96,8 +113,7 @@ func runTaskProjectRm(_ *cobra.Command, args []string) error {
 		})
 	}
 
-	client := newClient(cfg)
-	task, err := client.RemoveFromProject(context.Background(), taskGID, projectGID)
+	task, err := client.RemoveFromProject(ctx, taskGID, projectGID)
 	if err != nil {
 		return err
 	}
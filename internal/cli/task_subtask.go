@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/whoaa512/asana-cli/internal/api"
 	"github.com/whoaa512/asana-cli/internal/output"
 )
 
@@ -31,6 +32,7 @@ var taskSubtaskAddCmd = &cobra.Command{
 var (
 	subtaskListLimit  int
 	subtaskListOffset string
+	subtaskListPages  pageFlags
 	subtaskAddName    string
 )
 
@@ -41,6 +43,7 @@ func init() {
 
 	taskSubtaskListCmd.Flags().IntVar(&subtaskListLimit, "limit", 50, "Max results to return")
 	taskSubtaskListCmd.Flags().StringVar(&subtaskListOffset, "offset", "", "Pagination offset")
+	addPageFlags(taskSubtaskListCmd, &subtaskListPages)
 
 	taskSubtaskAddCmd.Flags().StringVar(&subtaskAddName, "name", "", "Subtask name (required)")
 	_ = taskSubtaskAddCmd.MarkFlagRequired("name")
@@ -56,13 +59,7 @@ func runTaskSubtaskList(_ *cobra.Command, args []string) error {
 	}
 
 	client := newClient(cfg)
-	result, err := client.ListSubtasks(context.Background(), args[0], subtaskListLimit, subtaskListOffset)
-	if err != nil {
-		return err
-	}
-
-	out := output.NewJSON(os.Stdout)
-	return out.Print(result)
+	return printList(context.Background(), &subtaskListPages, subtaskListLimit, subtaskListOffset, api.SubtaskPages(client, args[0]))
 }
 
 func runTaskSubtaskAdd(_ *cobra.Command, args []string) error {
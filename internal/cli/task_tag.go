@@ -15,14 +15,14 @@ var taskTagCmd = &cobra.Command{
 }
 
 var taskTagAddCmd = &cobra.Command{
-	Use:   "add <task_gid> <tag_gid>",
+	Use:   "add <task> <tag>",
 	Short: "Add a tag to a task",
 	Args:  cobra.ExactArgs(2),
 	RunE:  runTaskTagAdd,
 }
 
 var taskTagRmCmd = &cobra.Command{
-	Use:   "rm <task_gid> <tag_gid>",
+	Use:   "rm <task> <tag>",
 	Short: "Remove a tag from a task",
 	Args:  cobra.ExactArgs(2),
 	RunE:  runTaskTagRm,
@@ -32,6 +32,9 @@ func init() {
 	taskCmd.AddCommand(taskTagCmd)
 	taskTagCmd.AddCommand(taskTagAddCmd)
 	taskTagCmd.AddCommand(taskTagRmCmd)
+
+	taskTagAddCmd.Flags().BoolVar(&taskPick, "pick", false, "Show interactive picker if multiple matches")
+	taskTagRmCmd.Flags().BoolVar(&taskPick, "pick", false, "Show interactive picker if multiple matches")
 }
 
 func runTaskTagAdd(_ *cobra.Command, args []string) error {
@@ -43,8 +46,17 @@ func runTaskTagAdd(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	taskGID := args[0]
-	tagGID := args[1]
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	taskGID, err := resolveTaskGID(ctx, cfg, client, args[0], taskPick)
+	if err != nil {
+		return err
+	}
+	tagGID, err := resolveTagGID(ctx, cfg, client, args[1], taskPick)
+	if err != nil {
+		return err
+	}
 
 	if cfg.DryRun {
 		out := output.NewJSON(os.Stdout)
@@ -55,8 +67,7 @@ func runTaskTagAdd(_ *cobra.Command, args []string) error {
 		})
 	}
 
-	client := newClient(cfg)
-	task, err := client.AddTag(context.Background(), taskGID, tagGID)
+	task, err := client.AddTag(ctx, taskGID, tagGID)
 	if err != nil {
 		return err
 	}
@@ -74,8 +85,17 @@ func runTaskTagRm(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	taskGID := args[0]
-	tagGID := args[1]
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	taskGID, err := resolveTaskGID(ctx, cfg, client, args[0], taskPick)
+	if err != nil {
+		return err
+	}
+	tagGID, err := resolveTagGID(ctx, cfg, client, args[1], taskPick)
+	if err != nil {
+		return err
+	}
 
 	if cfg.DryRun {
 		out := output.NewJSON(os.Stdout)
@@ -87,8 +107,7 @@ func runTaskTagRm(_ *cobra.Command, args []string) error {
 		})
 	}
 
-	client := newClient(cfg)
-	task, err := client.RemoveTag(context.Background(), taskGID, tagGID)
+	task, err := client.RemoveTag(ctx, taskGID, tagGID)
 	if err != nil {
 		return err
 	}
@@ -43,6 +43,8 @@ var (
 	teamListOffset string
 	teamMeLimit    int
 	teamMeOffset   string
+	teamListPages  pageFlags
+	teamMePages    pageFlags
 )
 
 func init() {
@@ -53,9 +55,11 @@ func init() {
 
 	teamListCmd.Flags().IntVar(&teamListLimit, "limit", 50, "Max results to return")
 	teamListCmd.Flags().StringVar(&teamListOffset, "offset", "", "Pagination offset")
+	addPageFlags(teamListCmd, &teamListPages)
 
 	teamMeCmd.Flags().IntVar(&teamMeLimit, "limit", 50, "Max results to return")
 	teamMeCmd.Flags().StringVar(&teamMeOffset, "offset", "", "Pagination offset")
+	addPageFlags(teamMeCmd, &teamMePages)
 }
 
 func runTeamList(_ *cobra.Command, _ []string) error {
@@ -73,18 +77,10 @@ func runTeamList(_ *cobra.Command, _ []string) error {
 
 	opts := api.TeamListOptions{
 		Organization: cfg.Workspace,
-		Limit:        teamListLimit,
-		Offset:       teamListOffset,
 	}
 
 	client := newClient(cfg)
-	result, err := client.ListTeams(context.Background(), opts)
-	if err != nil {
-		return err
-	}
-
-	out := output.NewJSON(os.Stdout)
-	return out.Print(result)
+	return printList(context.Background(), &teamListPages, teamListLimit, teamListOffset, api.TeamPages(client, opts))
 }
 
 func runTeamMe(_ *cobra.Command, _ []string) error {
@@ -103,18 +99,10 @@ func runTeamMe(_ *cobra.Command, _ []string) error {
 	opts := api.UserTeamListOptions{
 		UserGID:      "me",
 		Organization: cfg.Workspace,
-		Limit:        teamMeLimit,
-		Offset:       teamMeOffset,
 	}
 
 	client := newClient(cfg)
-	result, err := client.ListUserTeams(context.Background(), opts)
-	if err != nil {
-		return err
-	}
-
-	out := output.NewJSON(os.Stdout)
-	return out.Print(result)
+	return printList(context.Background(), &teamMePages, teamMeLimit, teamMeOffset, api.UserTeamPages(client, opts))
 }
 
 func runTeamGet(_ *cobra.Command, args []string) error {
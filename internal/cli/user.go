@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/cache"
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/directory"
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Look up workspace members",
+	Long: `List, get and search the members of a workspace or team.
+
+Members are kept in a local directory (users/ under the response cache),
+refreshed daily or when a name isn't found, so --assignee and follower
+arguments can take an email or name.`,
+}
+
+var userListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List workspace or team members",
+	RunE:  runUserList,
+}
+
+var userGetCmd = &cobra.Command{
+	Use:   "get <user>",
+	Short: "Get user details",
+	Long:  "Get a user by GID, email, name or 'me'. Uses fuzzy matching for names.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runUserGet,
+}
+
+var userSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search members by name or email",
+	Long:  "Search the local member directory by name or email, fetching it first if missing or stale.",
+	Example: `  asana user search ada
+  asana user search ada@example.com --refresh`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUserSearch,
+}
+
+var (
+	userListTeam   string
+	userListLimit  int
+	userListOffset string
+	userListPages  pageFlags
+
+	userSearchLimit   int
+	userSearchRefresh bool
+
+	userPick bool
+)
+
+func init() {
+	rootCmd.AddCommand(userCmd)
+	userCmd.AddCommand(userListCmd)
+	userCmd.AddCommand(userGetCmd)
+	userCmd.AddCommand(userSearchCmd)
+
+	userListCmd.Flags().StringVar(&userListTeam, "team", "", "List members of a team (GID or name) instead of the workspace")
+	userListCmd.Flags().IntVar(&userListLimit, "limit", 50, "Max results to return")
+	userListCmd.Flags().StringVar(&userListOffset, "offset", "", "Pagination offset")
+	addPageFlags(userListCmd, &userListPages)
+
+	userGetCmd.Flags().BoolVar(&userPick, "pick", false, "Show interactive picker if multiple matches")
+
+	userSearchCmd.Flags().IntVar(&userSearchLimit, "limit", 20, "Max results to return (0 for all)")
+	userSearchCmd.Flags().BoolVar(&userSearchRefresh, "refresh", false, "Fetch the directory from Asana first")
+}
+
+func runUserList(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	opts := api.UserListOptions{Workspace: cfg.Workspace}
+	if opts.Team, err = resolveTeamGID(ctx, cfg, client, userListTeam, false); err != nil {
+		return err
+	}
+	if opts.Team == "" && opts.Workspace == "" {
+		return errors.NewGeneralError("no workspace specified", nil)
+	}
+
+	return printList(ctx, &userListPages, userListLimit, userListOffset, api.UserPages(client, opts))
+}
+
+func runUserGet(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	gid, err := resolveUserGID(ctx, cfg, client, args[0], userPick)
+	if err != nil {
+		return err
+	}
+
+	user, err := client.GetUser(ctx, gid)
+	if err != nil {
+		return err
+	}
+
+	out := newOutput()
+	return out.Print(user)
+}
+
+func runUserSearch(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	client := newClient(cfg)
+	ctx := context.Background()
+
+	dir, err := userDirectory(ctx, cfg, client, userSearchRefresh)
+	if err != nil {
+		return err
+	}
+
+	users := dir.Search(args[0])
+	if users == nil {
+		users = []models.User{}
+	}
+	if userSearchLimit > 0 && len(users) > userSearchLimit {
+		users = users[:userSearchLimit]
+	}
+
+	out := newOutput()
+	return out.Print(users)
+}
+
+func userDirectoryStore() *directory.Store {
+	return directory.NewStore(filepath.Join(cache.New("").Dir(), "users"))
+}
+
+// userDirectory returns the member directory for the configured workspace,
+// fetching and saving it if refresh is set or the saved copy is missing or
+// stale.
+func userDirectory(ctx context.Context, cfg *config.Config, client api.Client, refresh bool) (*directory.Directory, error) {
+	if cfg.Workspace == "" {
+		return nil, errors.NewGeneralError("no workspace specified", nil)
+	}
+
+	store := userDirectoryStore()
+	if !refresh {
+		if dir, err := store.Load(cfg.Workspace); err == nil && dir != nil && !dir.Stale(time.Now(), directory.DefaultTTL) {
+			return dir, nil
+		}
+	}
+
+	// The HTTP cache may hold the same member list the directory was built
+	// from, so it is bypassed to pick up new members.
+	result, err := api.Paginate(api.SkipCacheReads(ctx), api.PageOptions{}, api.UserPages(client, api.UserListOptions{Workspace: cfg.Workspace}))
+	if err != nil {
+		return nil, err
+	}
+
+	dir := &directory.Directory{Workspace: cfg.Workspace, FetchedAt: time.Now(), Users: result.Data}
+	// A directory that can't be saved is still good for this command.
+	_ = store.Save(dir)
+	return dir, nil
+}
+
+// userNamer returns a lookup from user GID to name backed by the member
+// directory, which is only loaded the first time a name is needed. Unknown
+// GIDs map to "".
+func userNamer(cfg *config.Config, client api.Client) func(ctx context.Context, gid string) string {
+	var (
+		once sync.Once
+		dir  *directory.Directory
+	)
+	return func(ctx context.Context, gid string) string {
+		once.Do(func() {
+			dir, _ = userDirectory(ctx, cfg, client, false)
+		})
+		if dir == nil {
+			return ""
+		}
+		return dir.Name(gid)
+	}
+}
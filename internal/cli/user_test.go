@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/cache"
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/directory"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+func TestResolveUserGIDFromDirectory(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/workspaces/99/users" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"data": [
+			{"gid": "1", "name": "Ada Lovelace", "email": "ada@example.com"},
+			{"gid": "2", "name": "Grace Hopper", "email": "grace@example.com"}
+		]}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second, Workspace: "99"}
+	client := api.NewHTTPClient(cfg, api.WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	// A stale-enough saved directory without Grace is refreshed on the miss.
+	stale := &directory.Directory{
+		Workspace: "99",
+		FetchedAt: time.Now().Add(-time.Hour),
+		Users:     []models.User{{GID: "1", Name: "Ada Lovelace", Email: "ada@example.com"}},
+	}
+	if err := userDirectoryStore().Save(stale); err != nil {
+		t.Fatal(err)
+	}
+
+	gid, err := resolveUserGID(ctx, cfg, client, "ada", false)
+	if err != nil || gid != "1" {
+		t.Fatalf("resolveUserGID(ada) = %q, %v, want 1", gid, err)
+	}
+	if requests != 0 {
+		t.Errorf("resolving a known name made %d requests, want 0", requests)
+	}
+
+	gid, err = resolveUserGID(ctx, cfg, client, "grace hopper", false)
+	if err != nil || gid != "2" {
+		t.Fatalf("resolveUserGID(grace hopper) = %q, %v, want 2", gid, err)
+	}
+	if requests != 1 {
+		t.Errorf("resolving a missing name made %d requests, want 1", requests)
+	}
+
+	saved, err := userDirectoryStore().Load("99")
+	if err != nil || saved == nil || len(saved.Users) != 2 {
+		t.Errorf("saved directory = %+v, %v, want the refreshed members", saved, err)
+	}
+}
+
+func TestNameAuthor(t *testing.T) {
+	names := func(_ context.Context, gid string) string {
+		if gid == "1" {
+			return "Ada Lovelace"
+		}
+		return ""
+	}
+	ctx := context.Background()
+
+	story := models.Story{CreatedBy: &models.AsanaResource{GID: "1"}}
+	nameAuthor(ctx, &story, names)
+	if story.CreatedBy.Name != "Ada Lovelace" {
+		t.Errorf("CreatedBy.Name = %q, want Ada Lovelace", story.CreatedBy.Name)
+	}
+
+	named := models.Story{CreatedBy: &models.AsanaResource{GID: "2", Name: "Asana Name"}}
+	nameAuthor(ctx, &named, names)
+	if named.CreatedBy.Name != "Asana Name" {
+		t.Errorf("CreatedBy.Name = %q, want the API's name kept", named.CreatedBy.Name)
+	}
+}
+
+func TestResolveUserGIDRefreshSkipsHTTPCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	users := `[{"gid": "1", "name": "Ada Lovelace"}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data": ` + users + `}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AccessToken: "test-token", Timeout: 5 * time.Second, Workspace: "99"}
+	client := api.NewHTTPClient(cfg, api.WithBaseURL(server.URL), api.WithCache(cache.New(t.TempDir())))
+	ctx := context.Background()
+
+	if gid, err := resolveUserGID(ctx, cfg, client, "ada", false); err != nil || gid != "1" {
+		t.Fatalf("resolveUserGID(ada) = %q, %v, want 1", gid, err)
+	}
+
+	// Grace joins; the saved directory is old enough to refresh on a miss
+	// while the member list is still in the HTTP cache.
+	users = `[{"gid": "1", "name": "Ada Lovelace"}, {"gid": "2", "name": "Grace Hopper"}]`
+	dir, err := userDirectoryStore().Load("99")
+	if err != nil || dir == nil {
+		t.Fatalf("Load() = %v, %v", dir, err)
+	}
+	dir.FetchedAt = time.Now().Add(-time.Hour)
+	if err := userDirectoryStore().Save(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if gid, err := resolveUserGID(ctx, cfg, client, "grace hopper", false); err != nil || gid != "2" {
+		t.Errorf("resolveUserGID(grace hopper) = %q, %v, want 2 after the refresh", gid, err)
+	}
+}
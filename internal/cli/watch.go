@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/output"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream change events for a project or task",
+	Long: `Stream change events for a project or task as NDJSON, one event per line.
+
+The sync token is saved after each batch is written, so a later watch on the
+same resource resumes where this one stopped. The first watch of a resource
+(or one run with --reset) starts from now.
+
+If the saved token has expired (Asana keeps them for about 24 hours), a
+warning is printed to stderr and the watch continues from now; events in
+between are lost.`,
+	Example: `  # Follow a project
+  asana watch --project 1234567890
+
+  # Poll once and exit (e.g. from cron or an agent loop)
+  asana watch --task 9876543210 --once`,
+	RunE: runWatch,
+}
+
+var (
+	watchProject  string
+	watchTask     string
+	watchInterval time.Duration
+	watchOnce     bool
+	watchReset    bool
+	watchSyncFile string
+)
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().StringVar(&watchProject, "project", "", "Project GID or name to watch (default from context)")
+	watchCmd.Flags().StringVar(&watchTask, "task", "", "Task GID or name to watch")
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Second, "Poll interval")
+	watchCmd.Flags().BoolVar(&watchOnce, "once", false, "Poll until caught up, then exit")
+	watchCmd.Flags().BoolVar(&watchReset, "reset", false, "Ignore the saved sync token and start from now")
+	watchCmd.Flags().StringVar(&watchSyncFile, "sync-file", "", "Where to persist the sync token (default ~/.config/asana-cli/watch/<gid>.json)")
+}
+
+type watchState struct {
+	Resource  string    `json:"resource"`
+	Sync      string    `json:"sync"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func runWatch(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	if watchProject != "" && watchTask != "" {
+		return errors.NewInvalidArgsError("use either --project or --task, not both")
+	}
+	client := newClient(cfg)
+	var resource string
+	if watchTask != "" {
+		resource, err = resolveTaskGID(context.Background(), cfg, client, watchTask, false)
+	} else {
+		resource, err = resolveProjectFlag(context.Background(), cfg, client, watchProject, false)
+	}
+	if err != nil {
+		return err
+	}
+	if resource == "" {
+		return errors.NewInvalidArgsError("--project or --task is required")
+	}
+
+	syncFile := watchSyncFile
+	if syncFile == "" {
+		syncFile = defaultSyncFile(cfg, resource)
+	}
+
+	var sync string
+	if !watchReset {
+		sync, err = loadSyncToken(syncFile, resource)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfg.DryRun {
+		out := output.NewJSON(os.Stdout)
+		return out.Print(map[string]any{
+			"dry_run":   true,
+			"resource":  resource,
+			"sync_file": syncFile,
+			"resuming":  sync != "",
+			"interval":  watchInterval.String(),
+			"once":      watchOnce,
+		})
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	stream := output.NewNDJSON(os.Stdout)
+	opts := api.WatchOptions{
+		Resource: resource,
+		Sync:     sync,
+		Interval: watchInterval,
+		Once:     watchOnce,
+	}
+
+	err = api.WatchEvents(ctx, client, opts, func(batch api.EventBatch) error {
+		if batch.Expired {
+			fmt.Fprintln(os.Stderr, "warning: sync token expired; events since the last watch were missed")
+		}
+		for _, event := range batch.Events {
+			if err := stream.WriteItem(event); err != nil {
+				return err
+			}
+		}
+		return saveSyncToken(syncFile, resource, batch.Sync)
+	})
+	if err != nil && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func defaultSyncFile(cfg *config.Config, resource string) string {
+	return filepath.Join(filepath.Dir(cfg.ConfigPath), "watch", resource+".json")
+}
+
+func loadSyncToken(path, resource string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", errors.NewGeneralError("failed to read sync file", err)
+	}
+
+	var state watchState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return "", errors.NewGeneralError("invalid sync file "+path, err)
+	}
+	if state.Resource != resource {
+		return "", nil
+	}
+	return state.Sync, nil
+}
+
+// saveSyncToken writes via a temp file and rename so an interrupted watch
+// never leaves a truncated token behind.
+func saveSyncToken(path, resource, sync string) error {
+	data, err := json.MarshalIndent(watchState{Resource: resource, Sync: sync, UpdatedAt: time.Now().UTC()}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return errors.NewGeneralError("failed to create sync directory", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return errors.NewGeneralError("failed to write sync file", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.NewGeneralError("failed to write sync file", err)
+	}
+	return nil
+}
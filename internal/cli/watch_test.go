@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSyncTokenRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watch", "123.json")
+
+	sync, err := loadSyncToken(path, "123")
+	if err != nil || sync != "" {
+		t.Fatalf("loadSyncToken() on missing file = %q, %v", sync, err)
+	}
+
+	if err := saveSyncToken(path, "123", "tok"); err != nil {
+		t.Fatalf("saveSyncToken() error = %v", err)
+	}
+
+	if sync, _ := loadSyncToken(path, "123"); sync != "tok" {
+		t.Errorf("sync = %q, want tok", sync)
+	}
+	if sync, _ := loadSyncToken(path, "456"); sync != "" {
+		t.Errorf("sync for other resource = %q, want empty", sync)
+	}
+}
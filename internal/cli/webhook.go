@@ -0,0 +1,399 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/whoaa512/asana-cli/internal/api"
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/models"
+	"github.com/whoaa512/asana-cli/internal/output"
+	"github.com/whoaa512/asana-cli/internal/webhook"
+)
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Manage and receive webhooks",
+	Long:  "Create, list, and delete Asana webhooks, and run a local receiver.",
+}
+
+var webhookCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a webhook",
+	Long: `Create a webhook for a resource. Asana performs a handshake with --target
+before responding, so start 'asana webhook serve' (reachable from the internet,
+e.g. through a tunnel) first.
+
+--filter takes resource_type[:action[:field,...]] and can be repeated.`,
+	Example: `  # Deliver task changes in a project to a tunnel
+  asana webhook create --resource 1234567890 --target https://example.ngrok.app/ \
+    --filter task:added --filter task:changed:completed`,
+	RunE: runWebhookCreate,
+}
+
+var webhookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List webhooks in the workspace",
+	RunE:  runWebhookList,
+}
+
+var webhookDeleteCmd = &cobra.Command{
+	Use:   "delete <gid>",
+	Short: "Delete a webhook",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWebhookDelete,
+}
+
+var webhookServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run a local webhook receiver",
+	Long: `Run an HTTP server that answers Asana's X-Hook-Secret handshake, verifies
+each delivery's X-Hook-Signature, and writes events to stdout as NDJSON.
+
+With --exec, the command is run through sh once per event instead, with the
+event JSON on stdin and these variables set:
+
+  ASANA_EVENT_ACTION     changed, added, removed, deleted, undeleted
+  ASANA_RESOURCE_GID     GID of the resource that changed
+  ASANA_RESOURCE_TYPE    task, story, section, ...
+  ASANA_PARENT_GID       GID of the parent (e.g. the section a task moved into)
+  ASANA_CHANGE_FIELD     field that changed, for "changed" events
+
+On SIGINT or SIGTERM the receiver stops accepting deliveries and finishes
+the events already queued, giving up on any still running after 30 seconds.
+
+Handshake secrets are saved to --secret-file so a restarted receiver keeps
+accepting deliveries. A new secret is only accepted while none is stored, so
+nobody else can register one and sign their own deliveries. Restart with
+--accept-handshake while creating each additional webhook.`,
+	Example: `  # Stream events
+  asana webhook serve --port 8080
+
+  # Kick off an agent run when a task lands in the in_progress section
+  asana webhook serve --exec '[ "$ASANA_PARENT_GID" = 1111 ] && ./run-agent.sh "$ASANA_RESOURCE_GID"'`,
+	RunE: runWebhookServe,
+}
+
+var (
+	webhookCreateResource string
+	webhookCreateTarget   string
+	webhookCreateFilters  []string
+
+	webhookListResource string
+	webhookListLimit    int
+	webhookListOffset   string
+	webhookListPages    pageFlags
+
+	webhookServePort       int
+	webhookServeHost       string
+	webhookServePath       string
+	webhookServeExec       string
+	webhookServeSecretFile string
+	webhookServeAccept     bool
+)
+
+func init() {
+	rootCmd.AddCommand(webhookCmd)
+	webhookCmd.AddCommand(webhookCreateCmd)
+	webhookCmd.AddCommand(webhookListCmd)
+	webhookCmd.AddCommand(webhookDeleteCmd)
+	webhookCmd.AddCommand(webhookServeCmd)
+
+	webhookCreateCmd.Flags().StringVar(&webhookCreateResource, "resource", "", "Resource GID to watch (default project from context)")
+	webhookCreateCmd.Flags().StringVar(&webhookCreateTarget, "target", "", "HTTPS URL Asana delivers to (required)")
+	webhookCreateCmd.Flags().StringArrayVar(&webhookCreateFilters, "filter", nil, "Event filter resource_type[:action[:fields]] (repeatable)")
+	_ = webhookCreateCmd.MarkFlagRequired("target")
+
+	webhookListCmd.Flags().StringVar(&webhookListResource, "resource", "", "Only webhooks on this resource")
+	webhookListCmd.Flags().IntVar(&webhookListLimit, "limit", 50, "Max results to return")
+	webhookListCmd.Flags().StringVar(&webhookListOffset, "offset", "", "Pagination offset")
+	addPageFlags(webhookListCmd, &webhookListPages)
+
+	webhookServeCmd.Flags().IntVar(&webhookServePort, "port", 8080, "Port to listen on")
+	webhookServeCmd.Flags().StringVar(&webhookServeHost, "host", "", "Interface to bind (default all)")
+	webhookServeCmd.Flags().StringVar(&webhookServePath, "path", "/", "URL path to receive deliveries on")
+	webhookServeCmd.Flags().StringVar(&webhookServeExec, "exec", "", "Shell command to run per event instead of printing")
+	webhookServeCmd.Flags().BoolVar(&webhookServeAccept, "accept-handshake", false, "Accept handshakes for new webhooks even if a secret is already stored")
+	webhookServeCmd.Flags().StringVar(&webhookServeSecretFile, "secret-file", "", "Where to persist handshake secrets (default ~/.config/asana-cli/webhook-secrets.json)")
+}
+
+func parseWebhookFilters(specs []string) ([]models.WebhookFilter, error) {
+	var filters []models.WebhookFilter
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, ":", 3)
+		if parts[0] == "" {
+			return nil, errors.NewInvalidArgsError(fmt.Sprintf("invalid --filter %q, expected resource_type[:action[:fields]]", spec))
+		}
+		f := models.WebhookFilter{ResourceType: parts[0]}
+		if len(parts) > 1 {
+			f.Action = parts[1]
+		}
+		if len(parts) > 2 {
+			f.Fields = splitList(parts[2])
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+func runWebhookCreate(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	resource := webhookCreateResource
+	if resource == "" {
+		resource = cfg.Project
+	}
+	if resource == "" {
+		return errors.NewInvalidArgsError("--resource is required (no project in context)")
+	}
+
+	filters, err := parseWebhookFilters(webhookCreateFilters)
+	if err != nil {
+		return err
+	}
+
+	req := models.WebhookCreateRequest{Resource: resource, Target: webhookCreateTarget, Filters: filters}
+
+	if cfg.DryRun {
+		out := output.NewJSON(os.Stdout)
+		return out.Print(map[string]any{"dry_run": true, "request": req})
+	}
+
+	client := newClient(cfg)
+	hook, err := client.CreateWebhook(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	out := output.NewJSON(os.Stdout)
+	return out.Print(hook)
+}
+
+func runWebhookList(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	if cfg.Workspace == "" {
+		return errors.NewGeneralError("workspace is required", nil)
+	}
+
+	client := newClient(cfg)
+	opts := api.WebhookListOptions{Workspace: cfg.Workspace, Resource: webhookListResource}
+	return printList(context.Background(), &webhookListPages, webhookListLimit, webhookListOffset, api.WebhookPages(client, opts))
+}
+
+func runWebhookDelete(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(cfg); err != nil {
+		return err
+	}
+
+	if cfg.DryRun {
+		out := output.NewJSON(os.Stdout)
+		return out.Print(map[string]any{"dry_run": true, "action": "delete", "gid": args[0]})
+	}
+
+	client := newClient(cfg)
+	if err := client.DeleteWebhook(context.Background(), args[0]); err != nil {
+		return err
+	}
+
+	out := output.NewJSON(os.Stdout)
+	return out.Print(map[string]any{"deleted": true, "gid": args[0]})
+}
+
+func runWebhookServe(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	secretFile := webhookServeSecretFile
+	if secretFile == "" {
+		secretFile = filepath.Join(filepath.Dir(cfg.ConfigPath), "webhook-secrets.json")
+	}
+	secrets, err := webhook.NewSecretStore(secretFile)
+	if err != nil {
+		return errors.NewGeneralError("failed to load webhook secrets", err)
+	}
+
+	addr := net.JoinHostPort(webhookServeHost, strconv.Itoa(webhookServePort))
+
+	if cfg.DryRun {
+		out := output.NewJSON(os.Stdout)
+		return out.Print(map[string]any{
+			"dry_run":          true,
+			"addr":             addr,
+			"path":             webhookServePath,
+			"exec":             webhookServeExec,
+			"secret_file":      secretFile,
+			"accept_handshake": webhookServeAccept,
+		})
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Deliveries are acknowledged immediately; a single worker handles
+	// events in arrival order.
+	queue := make(chan models.Event, 256)
+	stream := output.NewNDJSON(os.Stdout)
+	done := webhookWorker(ctx, queue, webhookDrainTimeout, func(ctx context.Context, event models.Event) error {
+		if webhookServeExec != "" {
+			return runWebhookExec(ctx, webhookServeExec, event)
+		}
+		return stream.WriteItem(event)
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle(webhookServePath, &webhook.Handler{
+		Secrets:         secrets,
+		AcceptHandshake: webhookServeAccept,
+		OnEvents: func(events []models.Event) {
+			for _, e := range events {
+				select {
+				case queue <- e:
+				case <-ctx.Done():
+					fmt.Fprintln(os.Stderr, "webhook: shutting down, dropped an event")
+					return
+				}
+			}
+		},
+		OnError: func(err error) {
+			fmt.Fprintf(os.Stderr, "webhook: %v\n", err)
+		},
+	})
+
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	errCh := make(chan error, 1)
+	go func() {
+		fmt.Fprintf(os.Stderr, "webhook: listening on %s%s\n", addr, webhookServePath)
+		errCh <- server.ListenAndServe()
+	}()
+
+	var serveErr error
+	select {
+	case serveErr = <-errCh:
+	case <-ctx.Done():
+	}
+	// Unblock handlers waiting on a full queue.
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// Only once every handler has returned is it safe to close the queue and
+	// let the worker drain it. If shutdown times out, handlers may still send,
+	// so the queue is left open for the process exit to clean up.
+	if err := server.Shutdown(shutdownCtx); err == nil {
+		close(queue)
+		<-done
+	} else {
+		fmt.Fprintf(os.Stderr, "webhook: shutdown: %v\n", err)
+	}
+
+	if serveErr != nil {
+		return errors.NewNetworkError("webhook server failed", serveErr)
+	}
+	return nil
+}
+
+// webhookDrainTimeout bounds how long queued events may take to handle once
+// the receiver is shutting down.
+const webhookDrainTimeout = 30 * time.Second
+
+// webhookWorker handles queued events in order until the queue is closed,
+// and closes the returned channel when it has finished. Events were already
+// acknowledged to Asana, so they run under a context that outlives ctx; once
+// ctx is done they get drain more time before that context is cancelled too.
+func webhookWorker(ctx context.Context, queue <-chan models.Event, drain time.Duration, handle func(context.Context, models.Event) error) <-chan struct{} {
+	work, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+			return
+		}
+		timer := time.NewTimer(drain)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-done:
+		}
+	}()
+	go func() {
+		defer close(done)
+		defer cancel()
+		for event := range queue {
+			if err := handle(work, event); err != nil {
+				fmt.Fprintf(os.Stderr, "webhook: %v\n", err)
+			}
+		}
+	}()
+	return done
+}
+
+func runWebhookExec(ctx context.Context, command string, event models.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), webhookEventEnv(event)...)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("--exec failed: %w", err)
+	}
+	return nil
+}
+
+func webhookEventEnv(event models.Event) []string {
+	env := []string{"ASANA_EVENT_ACTION=" + event.Action}
+	if event.Resource != nil {
+		env = append(env,
+			"ASANA_RESOURCE_GID="+event.Resource.GID,
+			"ASANA_RESOURCE_TYPE="+event.Resource.ResourceType,
+		)
+	}
+	if event.Parent != nil {
+		env = append(env, "ASANA_PARENT_GID="+event.Parent.GID)
+	}
+	if event.Change != nil {
+		env = append(env, "ASANA_CHANGE_FIELD="+event.Change.Field)
+	}
+	return env
+}
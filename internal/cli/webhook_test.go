@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+func TestParseWebhookFilters(t *testing.T) {
+	got, err := parseWebhookFilters([]string{"task", "task:added", "task:changed:completed,due_on"})
+	if err != nil {
+		t.Fatalf("parseWebhookFilters() error = %v", err)
+	}
+
+	want := []models.WebhookFilter{
+		{ResourceType: "task"},
+		{ResourceType: "task", Action: "added"},
+		{ResourceType: "task", Action: "changed", Fields: []string{"completed", "due_on"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filters = %+v, want %+v", got, want)
+	}
+
+	if _, err := parseWebhookFilters([]string{":added"}); err == nil {
+		t.Error("expected error for empty resource type")
+	}
+}
+
+func TestWebhookWorkerDrainsAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	queue := make(chan models.Event, 3)
+	for _, action := range []string{"added", "changed", "removed"} {
+		queue <- models.Event{Action: action}
+	}
+
+	var handled []string
+	done := webhookWorker(ctx, queue, time.Minute, func(ctx context.Context, e models.Event) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		handled = append(handled, e.Action)
+		return nil
+	})
+	cancel()
+	close(queue)
+	<-done
+
+	if want := []string{"added", "changed", "removed"}; !reflect.DeepEqual(handled, want) {
+		t.Errorf("handled = %v, want %v", handled, want)
+	}
+}
+
+func TestWebhookWorkerGivesUpAfterDrain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	queue := make(chan models.Event, 1)
+	queue <- models.Event{Action: "changed"}
+	close(queue)
+
+	var err error
+	done := webhookWorker(ctx, queue, 10*time.Millisecond, func(ctx context.Context, _ models.Event) error {
+		cancel()
+		<-ctx.Done()
+		err = ctx.Err()
+		return nil
+	})
+	<-done
+
+	if err != context.Canceled {
+		t.Errorf("handler context error = %v, want it cancelled once the drain time is up", err)
+	}
+}
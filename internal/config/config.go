@@ -20,19 +20,49 @@ type Config struct {
 	Project          string            `json:"-"`
 	Task             string            `json:"-"`
 	Sections         map[string]string `json:"-"`
+	Scoring          *Scoring          `json:"-"`
+	RateLimit        *RateLimit        `json:"rate_limit,omitempty"`
+	Retry            *Retry            `json:"retry,omitempty"`
+	Session          *Session          `json:"session,omitempty"`
 	Timeout          time.Duration     `json:"-"`
 	TimeoutStr       string            `json:"timeout,omitempty"`
 	Debug            bool              `json:"debug,omitempty"`
 	DryRun           bool              `json:"-"`
+	NoCache          bool              `json:"-"`
 	ConfigPath       string            `json:"-"`
 	LocalContextPath string            `json:"-"`
 	configFileLoaded bool
 }
 
+// RateLimit overrides the client-side request budgets. Zero fields keep the
+// defaults, which assume a paid workspace; free workspaces get a tenth of
+// the per-minute quota.
+type RateLimit struct {
+	ReadsPerMinute   int `json:"reads_per_minute,omitempty"`
+	WritesPerMinute  int `json:"writes_per_minute,omitempty"`
+	ConcurrentReads  int `json:"concurrent_reads,omitempty"`
+	ConcurrentWrites int `json:"concurrent_writes,omitempty"`
+}
+
+// Retry overrides the retry policy for failed requests. MaxRetries is a
+// pointer so 0 can turn retries off; backoffs are durations like "500ms".
+type Retry struct {
+	MaxRetries     *int   `json:"max_retries,omitempty"`
+	InitialBackoff string `json:"initial_backoff,omitempty"`
+	MaxBackoff     string `json:"max_backoff,omitempty"`
+}
+
+// Session configures work sessions. IdleTimeout is a duration like "30m"
+// after which a session with no new logs is paused; "0" turns it off.
+type Session struct {
+	IdleTimeout string `json:"idle_timeout,omitempty"`
+}
+
 type Flags struct {
 	Workspace  string
 	Debug      bool
 	DryRun     bool
+	NoCache    bool
 	Timeout    time.Duration
 	ConfigPath string
 }
@@ -85,6 +115,9 @@ func (c *Config) loadFromLocalContext() error {
 	if ctx.Sections != nil {
 		c.Sections = ctx.Sections
 	}
+	if ctx.Scoring != nil {
+		c.Scoring = ctx.Scoring
+	}
 	return nil
 }
 
@@ -95,10 +128,13 @@ func (c *Config) loadFromFile(path string) error {
 	}
 
 	var fileConfig struct {
-		DefaultWorkspace string `json:"default_workspace"`
-		DefaultTeam      string `json:"default_team"`
-		Timeout          string `json:"timeout"`
-		Debug            bool   `json:"debug"`
+		DefaultWorkspace string     `json:"default_workspace"`
+		DefaultTeam      string     `json:"default_team"`
+		Timeout          string     `json:"timeout"`
+		Debug            bool       `json:"debug"`
+		RateLimit        *RateLimit `json:"rate_limit"`
+		Retry            *Retry     `json:"retry"`
+		Session          *Session   `json:"session"`
 	}
 
 	if err := json.Unmarshal(data, &fileConfig); err != nil {
@@ -119,6 +155,15 @@ func (c *Config) loadFromFile(path string) error {
 	if fileConfig.Debug {
 		c.Debug = true
 	}
+	if fileConfig.RateLimit != nil {
+		c.RateLimit = fileConfig.RateLimit
+	}
+	if fileConfig.Retry != nil {
+		c.Retry = fileConfig.Retry
+	}
+	if fileConfig.Session != nil {
+		c.Session = fileConfig.Session
+	}
 	c.configFileLoaded = true
 	return nil
 }
@@ -133,6 +178,9 @@ func (c *Config) loadFromEnv() {
 	if debug := os.Getenv("ASANA_DEBUG"); debug != "" {
 		c.Debug = parseBool(debug)
 	}
+	if noCache := os.Getenv("ASANA_NO_CACHE"); noCache != "" {
+		c.NoCache = parseBool(noCache)
+	}
 }
 
 func (c *Config) applyFlags(flags *Flags) {
@@ -145,6 +193,9 @@ func (c *Config) applyFlags(flags *Flags) {
 	if flags.DryRun {
 		c.DryRun = true
 	}
+	if flags.NoCache {
+		c.NoCache = true
+	}
 	if flags.Timeout > 0 {
 		c.Timeout = flags.Timeout
 	}
@@ -50,7 +50,7 @@ func TestLoadFromEnv(t *testing.T) {
 func TestLoadFromFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "config.json")
-	content := `{"default_workspace": "99999", "timeout": "60s", "debug": true}`
+	content := `{"default_workspace": "99999", "timeout": "60s", "debug": true, "rate_limit": {"reads_per_minute": 120}, "retry": {"max_retries": 0}, "session": {"idle_timeout": "30m"}}`
 	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
 		t.Fatal(err)
 	}
@@ -74,6 +74,15 @@ func TestLoadFromFile(t *testing.T) {
 	if !cfg.Debug {
 		t.Error("Debug should be true from file")
 	}
+	if cfg.RateLimit == nil || cfg.RateLimit.ReadsPerMinute != 120 || cfg.RateLimit.WritesPerMinute != 0 {
+		t.Errorf("RateLimit = %+v, want reads_per_minute 120 only", cfg.RateLimit)
+	}
+	if cfg.Retry == nil || cfg.Retry.MaxRetries == nil || *cfg.Retry.MaxRetries != 0 {
+		t.Errorf("Retry = %+v, want max_retries explicitly 0", cfg.Retry)
+	}
+	if cfg.Session == nil || cfg.Session.IdleTimeout != "30m" {
+		t.Errorf("Session = %+v, want idle_timeout 30m", cfg.Session)
+	}
 }
 
 func TestFlagsOverrideEnv(t *testing.T) {
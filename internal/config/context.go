@@ -13,9 +13,19 @@ type LocalContext struct {
 	Project   string            `json:"project,omitempty"`
 	Task      string            `json:"task,omitempty"`
 	Sections  map[string]string `json:"sections,omitempty"`
+	Scoring   *Scoring          `json:"scoring,omitempty"`
 	path      string
 }
 
+// Scoring tunes 'ready --sort score'. Weights override the default weight
+// of each factor (due, unblocks, position, tags, assignee); Tags gives a
+// bonus, or a penalty if negative, to tasks carrying a tag, matched by name
+// or GID.
+type Scoring struct {
+	Weights map[string]float64 `json:"weights,omitempty"`
+	Tags    map[string]float64 `json:"tags,omitempty"`
+}
+
 func (lc *LocalContext) Path() string {
 	return lc.path
 }
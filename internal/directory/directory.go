@@ -0,0 +1,126 @@
+// Package directory keeps a local copy of each workspace's members, so users
+// can be named by email or name, and shown by name, without an API call.
+package directory
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+// DefaultTTL is how long a directory is trusted before it is fetched again.
+// Membership changes rarely, and a miss triggers a refresh anyway.
+const DefaultTTL = 24 * time.Hour
+
+type Directory struct {
+	Workspace string        `json:"workspace"`
+	FetchedAt time.Time     `json:"fetched_at"`
+	Users     []models.User `json:"users"`
+}
+
+// Stale reports whether the directory is older than ttl at now.
+func (d *Directory) Stale(now time.Time, ttl time.Duration) bool {
+	return now.Sub(d.FetchedAt) >= ttl
+}
+
+// Get returns the user with the given GID or email.
+func (d *Directory) Get(gidOrEmail string) (models.User, bool) {
+	for _, u := range d.Users {
+		if u.GID == gidOrEmail || (u.Email != "" && strings.EqualFold(u.Email, gidOrEmail)) {
+			return u, true
+		}
+	}
+	return models.User{}, false
+}
+
+// Name returns the name of the user with gid, or "" if unknown.
+func (d *Directory) Name(gid string) string {
+	u, _ := d.Get(gid)
+	return u.Name
+}
+
+// Search returns users whose name or email matches query exactly (ignoring
+// case), or failing that fuzzily, best match first.
+func (d *Directory) Search(query string) []models.User {
+	var exact []models.User
+	for _, u := range d.Users {
+		if strings.EqualFold(u.Name, query) || (u.Email != "" && strings.EqualFold(u.Email, query)) {
+			exact = append(exact, u)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+
+	targets := make([]string, len(d.Users))
+	for i, u := range d.Users {
+		targets[i] = u.Name + " " + u.Email
+	}
+	var matches []models.User
+	for _, m := range fuzzy.Find(query, targets) {
+		matches = append(matches, d.Users[m.Index])
+	}
+	return matches
+}
+
+// Store reads and writes directories as one JSON file per workspace.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store rooted at dir, normally the "users" directory
+// under the response cache.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+func (s *Store) path(workspace string) string {
+	return filepath.Join(s.dir, workspace+".json")
+}
+
+// Load returns the saved directory for workspace, or nil if there is none.
+func (s *Store) Load(workspace string) (*Directory, error) {
+	data, err := os.ReadFile(s.path(workspace))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var d Directory
+	if err := json.Unmarshal(data, &d); err != nil {
+		// A corrupt file is treated as missing and overwritten on refresh.
+		return nil, nil
+	}
+	return &d, nil
+}
+
+func (s *Store) Save(d *Directory) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(d.Workspace))
+}
@@ -0,0 +1,106 @@
+package directory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+var testUsers = []models.User{
+	{GID: "1", Name: "Ada Lovelace", Email: "ada@example.com"},
+	{GID: "2", Name: "Alan Turing", Email: "alan@example.com"},
+	{GID: "3", Name: "Grace Hopper", Email: "grace@example.com"},
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	s := NewStore(filepath.Join(t.TempDir(), "users"))
+
+	d, err := s.Load("99")
+	if err != nil || d != nil {
+		t.Fatalf("Load() before Save = %v, %v, want nil, nil", d, err)
+	}
+
+	fetched := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := s.Save(&Directory{Workspace: "99", FetchedAt: fetched, Users: testUsers}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	d, err = s.Load("99")
+	if err != nil || d == nil {
+		t.Fatalf("Load() = %v, %v", d, err)
+	}
+	if !d.FetchedAt.Equal(fetched) || len(d.Users) != 3 {
+		t.Errorf("Load() = %+v, want the saved directory", d)
+	}
+}
+
+func TestStoreLoadCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "99.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := NewStore(dir).Load("99")
+	if err != nil || d != nil {
+		t.Errorf("Load() of corrupt file = %v, %v, want nil, nil", d, err)
+	}
+}
+
+func TestStale(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	d := &Directory{FetchedAt: now.Add(-time.Hour)}
+
+	if d.Stale(now, DefaultTTL) {
+		t.Error("Stale() = true for an hour-old directory")
+	}
+	if !d.Stale(now.Add(DefaultTTL), DefaultTTL) {
+		t.Error("Stale() = false past the TTL")
+	}
+}
+
+func TestGetAndName(t *testing.T) {
+	d := &Directory{Users: testUsers}
+
+	if u, ok := d.Get("GRACE@example.com"); !ok || u.GID != "3" {
+		t.Errorf("Get(email) = %+v, %v, want Grace", u, ok)
+	}
+	if got := d.Name("2"); got != "Alan Turing" {
+		t.Errorf("Name(2) = %q, want Alan Turing", got)
+	}
+	if got := d.Name("404"); got != "" {
+		t.Errorf("Name(404) = %q, want empty", got)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	d := &Directory{Users: testUsers}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"ada lovelace", []string{"1"}},
+		{"alan@example.com", []string{"2"}},
+		{"hopper", []string{"3"}},
+		{"zzz", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var got []string
+			for _, u := range d.Search(tt.query) {
+				got = append(got, u.GID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ type CLIError struct {
 	Message  string `json:"message"`
 	Code     string `json:"code"`
 	ExitCode int    `json:"exit_code"`
+	Details  any    `json:"details,omitempty"`
 	Cause    error  `json:"-"`
 }
 
@@ -78,6 +79,17 @@ func NewRateLimitedError(retryAfter string) *CLIError {
 	}
 }
 
+// NewDependencyCycleError reports a dependency that would close a cycle.
+// cycle lists the tasks along it, starting and ending with the same task.
+func NewDependencyCycleError(msg string, cycle any) *CLIError {
+	return &CLIError{
+		Message:  msg,
+		Code:     "DEPENDENCY_CYCLE",
+		ExitCode: ExitInvalidArgs,
+		Details:  map[string]any{"cycle": cycle},
+	}
+}
+
 func NewNetworkError(msg string, cause error) *CLIError {
 	return &CLIError{
 		Message:  msg,
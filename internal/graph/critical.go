@@ -0,0 +1,186 @@
+package graph
+
+import "sort"
+
+// schedule walks incomplete dependencies to find, for each task, the chain
+// that gates it. A task's projected finish is the latest of its own due date
+// and its dependencies' projected finishes; the gating dependency is the one
+// with the latest projected finish, breaking ties by the longer chain. Due
+// dates are YYYY-MM-DD, so they compare as strings.
+type schedule struct {
+	g        *Graph
+	finish   map[string]string
+	depth    map[string]int
+	next     map[string]string
+	visiting map[string]bool
+}
+
+func newSchedule(g *Graph) *schedule {
+	return &schedule{
+		g:        g,
+		finish:   map[string]string{},
+		depth:    map[string]int{},
+		next:     map[string]string{},
+		visiting: map[string]bool{},
+	}
+}
+
+func (s *schedule) visit(gid string) {
+	if _, done := s.depth[gid]; done || s.visiting[gid] {
+		return
+	}
+	s.visiting[gid] = true
+	defer delete(s.visiting, gid)
+
+	n := s.g.nodes[gid]
+	finish, depth, next := n.DueOn, 1, ""
+	for _, dep := range s.g.incompleteDeps(gid) {
+		// A dependency still being visited closes a cycle; skip the back
+		// edge so the chain stays finite.
+		if s.visiting[dep] {
+			continue
+		}
+		s.visit(dep)
+		if s.finish[dep] > s.finishVia(next) || (s.finish[dep] == s.finishVia(next) && s.depth[dep]+1 > depth) {
+			next = dep
+			depth = s.depth[dep] + 1
+		}
+		if s.finish[dep] > finish {
+			finish = s.finish[dep]
+		}
+	}
+	s.finish[gid] = finish
+	s.depth[gid] = depth
+	s.next[gid] = next
+}
+
+func (s *schedule) finishVia(gid string) string {
+	if gid == "" {
+		return ""
+	}
+	return s.finish[gid]
+}
+
+// chain returns gid followed by its gating dependencies down to a root.
+func (s *schedule) chain(gid string) []string {
+	s.visit(gid)
+	path := []string{gid}
+	for at := s.next[gid]; at != ""; at = s.next[at] {
+		path = append(path, at)
+	}
+	return path
+}
+
+func (g *Graph) incompleteDeps(gid string) []string {
+	var deps []string
+	for _, dep := range g.nodes[gid].DependsOn {
+		if d := g.nodes[dep]; d != nil && !d.Completed {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// Blocking describes everything standing between a task and being ready.
+type Blocking struct {
+	// Direct are the task's own incomplete dependencies.
+	Direct []string
+	// Roots are the incomplete tasks, reachable through incomplete
+	// dependencies, that are not blocked themselves: the work to do first.
+	Roots []string
+	// Chain is the gating chain from the task's first blocker down to a
+	// root, as chosen by the critical path rules.
+	Chain []string
+}
+
+func (g *Graph) Blocking(gid string) Blocking {
+	b := Blocking{Direct: g.incompleteDeps(gid)}
+	if len(b.Direct) == 0 {
+		return b
+	}
+
+	seen := map[string]bool{gid: true}
+	queue := append([]string(nil), b.Direct...)
+	for _, dep := range queue {
+		seen[dep] = true
+	}
+	for len(queue) > 0 {
+		at := queue[0]
+		queue = queue[1:]
+		deps := g.incompleteDeps(at)
+		if len(deps) == 0 {
+			b.Roots = append(b.Roots, at)
+		}
+		for _, dep := range deps {
+			if !seen[dep] {
+				seen[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	b.Chain = newSchedule(g).chain(gid)[1:]
+	return b
+}
+
+type CriticalPath struct {
+	Leaf string
+	// Tasks runs from the root blocker to the leaf.
+	Tasks []string
+	// Finish is the projected finish of the leaf: the latest due date along
+	// its dependencies. It is empty when no task in the chain has a due date.
+	Finish string
+}
+
+// Late reports whether the chain's projected finish is after the leaf's own
+// due date.
+func (g *Graph) Late(p CriticalPath) bool {
+	due := g.nodes[p.Leaf].DueOn
+	return due != "" && p.Finish > due
+}
+
+// ProjectedFinish is the latest due date among gid and its incomplete
+// dependencies, transitively.
+func (g *Graph) ProjectedFinish(gid string) string {
+	s := newSchedule(g)
+	s.visit(gid)
+	return s.finish[gid]
+}
+
+// CriticalPaths returns the gating chain to every incomplete leaf (a task no
+// incomplete task depends on) that has at least one incomplete dependency.
+// Longer chains come first, then later projected finishes.
+func (g *Graph) CriticalPaths() []CriticalPath {
+	hasDependent := map[string]bool{}
+	for _, n := range g.nodes {
+		if n.Completed {
+			continue
+		}
+		for _, dep := range n.DependsOn {
+			hasDependent[dep] = true
+		}
+	}
+
+	s := newSchedule(g)
+	var paths []CriticalPath
+	for _, gid := range g.order {
+		n := g.nodes[gid]
+		if n.Completed || n.External || hasDependent[gid] || len(g.incompleteDeps(gid)) == 0 {
+			continue
+		}
+		chain := s.chain(gid)
+		tasks := make([]string, len(chain))
+		for i, t := range chain {
+			tasks[len(chain)-1-i] = t
+		}
+		paths = append(paths, CriticalPath{Leaf: gid, Tasks: tasks, Finish: s.finish[gid]})
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		if len(paths[i].Tasks) != len(paths[j].Tasks) {
+			return len(paths[i].Tasks) > len(paths[j].Tasks)
+		}
+		return paths[i].Finish > paths[j].Finish
+	})
+	return paths
+}
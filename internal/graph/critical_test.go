@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+// releaseGraph: Release depends on Build and Docs; Build depends on Design
+// and Infra; Docs is short but due late.
+//
+//	Design (03-01) <- Build (03-05) <- Release (03-10)
+//	Infra  (03-20) <-/                /
+//	Docs   (03-15) <-----------------/
+func releaseGraph() *Graph {
+	g := New()
+	g.AddTask(models.Task{GID: "r", Name: "Release", DueOn: "2026-03-10", Dependencies: deps(models.Task{GID: "b"}, models.Task{GID: "d"})}, "")
+	g.AddTask(models.Task{GID: "b", Name: "Build", DueOn: "2026-03-05", Dependencies: deps(models.Task{GID: "x"}, models.Task{GID: "i"})}, "")
+	g.AddTask(models.Task{GID: "d", Name: "Docs", DueOn: "2026-03-15", Dependencies: deps()}, "")
+	g.AddTask(models.Task{GID: "x", Name: "Design", DueOn: "2026-03-01", Dependencies: deps(models.Task{GID: "old", Completed: true})}, "")
+	g.AddTask(models.Task{GID: "i", Name: "Infra", DueOn: "2026-03-20", Dependencies: deps()}, "")
+	return g
+}
+
+func TestGraph_Blocking(t *testing.T) {
+	g := releaseGraph()
+
+	b := g.Blocking("r")
+	if !reflect.DeepEqual(b.Direct, []string{"b", "d"}) {
+		t.Errorf("Direct = %v", b.Direct)
+	}
+	if !reflect.DeepEqual(b.Roots, []string{"d", "x", "i"}) {
+		t.Errorf("Roots = %v", b.Roots)
+	}
+	// Infra finishes last, so it gates Build and therefore Release.
+	if !reflect.DeepEqual(b.Chain, []string{"b", "i"}) {
+		t.Errorf("Chain = %v", b.Chain)
+	}
+
+	if b := g.Blocking("x"); len(b.Direct) != 0 || b.Chain != nil {
+		t.Errorf("Blocking(Design) = %+v, want unblocked (its dependency is complete)", b)
+	}
+}
+
+func TestGraph_CriticalPaths(t *testing.T) {
+	g := releaseGraph()
+
+	paths := g.CriticalPaths()
+	if len(paths) != 1 {
+		t.Fatalf("got %d paths, want 1 (Release is the only leaf)", len(paths))
+	}
+	p := paths[0]
+	if !reflect.DeepEqual(p.Tasks, []string{"i", "b", "r"}) {
+		t.Errorf("Tasks = %v", p.Tasks)
+	}
+	if p.Finish != "2026-03-20" || !g.Late(p) {
+		t.Errorf("Finish = %s, Late = %v; want 2026-03-20, late", p.Finish, g.Late(p))
+	}
+	if got := g.ProjectedFinish("b"); got != "2026-03-20" {
+		t.Errorf("ProjectedFinish(Build) = %s", got)
+	}
+}
+
+func TestGraph_CriticalPathsWithoutDates(t *testing.T) {
+	g := New()
+	g.AddTask(models.Task{GID: "a", Dependencies: deps(models.Task{GID: "b"}, models.Task{GID: "c"})}, "")
+	g.AddTask(models.Task{GID: "b", Dependencies: deps()}, "")
+	g.AddTask(models.Task{GID: "c", Dependencies: deps(models.Task{GID: "e"})}, "")
+	g.AddTask(models.Task{GID: "e", Dependencies: deps()}, "")
+
+	paths := g.CriticalPaths()
+	if len(paths) != 1 || !reflect.DeepEqual(paths[0].Tasks, []string{"e", "c", "a"}) {
+		t.Errorf("paths = %+v, want the longest chain e -> c -> a", paths)
+	}
+	if g.Late(paths[0]) {
+		t.Error("a chain without dates cannot be late")
+	}
+}
+
+func TestGraph_CriticalPathsTerminateOnCycles(t *testing.T) {
+	g := New()
+	g.AddTask(models.Task{GID: "leaf", Dependencies: deps(models.Task{GID: "a"})}, "")
+	g.AddTask(models.Task{GID: "a", Dependencies: deps(models.Task{GID: "b"})}, "")
+	g.AddTask(models.Task{GID: "b", Dependencies: deps(models.Task{GID: "a"})}, "")
+
+	paths := g.CriticalPaths()
+	if len(paths) != 1 || len(paths[0].Tasks) != 3 {
+		t.Errorf("paths = %+v", paths)
+	}
+	if b := g.Blocking("leaf"); len(b.Roots) != 0 || len(b.Chain) != 2 {
+		t.Errorf("Blocking(leaf) = %+v, want a chain but no root (everything is deadlocked)", b)
+	}
+}
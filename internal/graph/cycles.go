@@ -0,0 +1,169 @@
+package graph
+
+import "github.com/whoaa512/asana-cli/internal/models"
+
+// SetDependencies records the dependencies of a node without touching its
+// other fields, so external nodes can be expanded while walking across
+// projects.
+func (g *Graph) SetDependencies(gid string, deps []models.Task) {
+	n := g.node(gid)
+	n.DependsOn = n.DependsOn[:0]
+	for _, dep := range deps {
+		n.DependsOn = append(n.DependsOn, dep.GID)
+		if _, ok := g.nodes[dep.GID]; !ok {
+			d := g.node(dep.GID)
+			d.Name = dep.Name
+			d.Completed = dep.Completed
+			d.External = true
+		}
+	}
+}
+
+// Path returns the shortest chain from -> ... -> to following dependencies
+// (each task to a task it depends on), or nil if to is not reachable.
+func (g *Graph) Path(from, to string) []string {
+	if _, ok := g.nodes[from]; !ok {
+		return nil
+	}
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		gid := queue[0]
+		queue = queue[1:]
+		if gid == to {
+			var path []string
+			for at := to; at != ""; at = prev[at] {
+				path = append([]string{at}, path...)
+			}
+			return path
+		}
+		n := g.nodes[gid]
+		if n == nil {
+			continue
+		}
+		for _, dep := range n.DependsOn {
+			if _, seen := prev[dep]; !seen {
+				prev[dep] = gid
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// Cycles returns one cycle per strongly connected component of the
+// dependency graph, each starting and ending with the same task.
+func (g *Graph) Cycles() [][]string {
+	var cycles [][]string
+	for _, component := range g.components() {
+		start := component[0]
+		if len(component) == 1 && !g.dependsOn(start, start) {
+			continue
+		}
+		cycle := g.cycleThrough(start, component)
+		if cycle != nil {
+			cycles = append(cycles, cycle)
+		}
+	}
+	return cycles
+}
+
+func (g *Graph) dependsOn(gid, dep string) bool {
+	for _, d := range g.nodes[gid].DependsOn {
+		if d == dep {
+			return true
+		}
+	}
+	return false
+}
+
+// cycleThrough finds the shortest cycle from start back to itself through a
+// dependency inside its component.
+func (g *Graph) cycleThrough(start string, component []string) []string {
+	members := make(map[string]bool, len(component))
+	for _, gid := range component {
+		members[gid] = true
+	}
+
+	var best []string
+	for _, dep := range g.nodes[start].DependsOn {
+		if !members[dep] {
+			continue
+		}
+		path := g.Path(dep, start)
+		if path != nil && (best == nil || len(path) < len(best)) {
+			best = path
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return append([]string{start}, best...)
+}
+
+// components runs Tarjan's algorithm. Each component is ordered by
+// insertion, so the reported cycle starts from the first task seen.
+func (g *Graph) components() [][]string {
+	index := map[string]int{}
+	low := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var result [][]string
+	next := 0
+
+	var visit func(gid string)
+	visit = func(gid string) {
+		index[gid] = next
+		low[gid] = next
+		next++
+		stack = append(stack, gid)
+		onStack[gid] = true
+
+		for _, dep := range g.nodes[gid].DependsOn {
+			if _, ok := g.nodes[dep]; !ok {
+				continue
+			}
+			if _, seen := index[dep]; !seen {
+				visit(dep)
+				low[gid] = min(low[gid], low[dep])
+			} else if onStack[dep] {
+				low[gid] = min(low[gid], index[dep])
+			}
+		}
+
+		if low[gid] == index[gid] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == gid {
+					break
+				}
+			}
+			result = append(result, g.inOrder(component))
+		}
+	}
+
+	for _, gid := range g.order {
+		if _, seen := index[gid]; !seen {
+			visit(gid)
+		}
+	}
+	return result
+}
+
+func (g *Graph) inOrder(gids []string) []string {
+	set := make(map[string]bool, len(gids))
+	for _, gid := range gids {
+		set[gid] = true
+	}
+	ordered := make([]string, 0, len(gids))
+	for _, gid := range g.order {
+		if set[gid] {
+			ordered = append(ordered, gid)
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,144 @@
+// Package graph models the dependency and subtask structure of a set of
+// tasks and renders it as DOT, Mermaid or JSON.
+package graph
+
+import (
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+const (
+	StatusCompleted = "completed"
+	StatusReady     = "ready"
+	StatusBlocked   = "blocked"
+)
+
+type Node struct {
+	GID       string `json:"gid"`
+	Name      string `json:"name"`
+	Completed bool   `json:"completed"`
+	Status    string `json:"status"`
+	DueOn     string `json:"due_on,omitempty"`
+	Section   string `json:"section,omitempty"`
+	Parent    string `json:"parent,omitempty"`
+	// External nodes are dependencies outside the walked set; only their
+	// compact fields are known.
+	External bool `json:"external,omitempty"`
+
+	DependsOn []string `json:"-"`
+}
+
+const (
+	EdgeDependency = "dependency"
+	EdgeSubtask    = "subtask"
+)
+
+// Edge points from a blocker to the task it blocks, or from a parent to its
+// subtask.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+type Graph struct {
+	nodes map[string]*Node
+	order []string
+}
+
+func New() *Graph {
+	return &Graph{nodes: map[string]*Node{}}
+}
+
+// AddTask adds a task fetched with its dependencies. Dependencies not yet in
+// the graph are added as external nodes, and are upgraded in place if the
+// full task is added later.
+func (g *Graph) AddTask(task models.Task, section string) *Node {
+	n := g.node(task.GID)
+	n.Name = task.Name
+	n.Completed = task.Completed
+	n.DueOn = task.DueOn
+	n.Section = section
+	n.External = false
+	if task.Parent != nil {
+		n.Parent = task.Parent.GID
+	}
+
+	if task.Dependencies != nil {
+		g.SetDependencies(task.GID, *task.Dependencies)
+	}
+	return n
+}
+
+func (g *Graph) node(gid string) *Node {
+	if n, ok := g.nodes[gid]; ok {
+		return n
+	}
+	n := &Node{GID: gid}
+	g.nodes[gid] = n
+	g.order = append(g.order, gid)
+	return n
+}
+
+func (g *Graph) Node(gid string) *Node {
+	return g.nodes[gid]
+}
+
+// Nodes returns every node in insertion order with Status filled in.
+func (g *Graph) Nodes() []*Node {
+	nodes := make([]*Node, 0, len(g.order))
+	for _, gid := range g.order {
+		n := g.nodes[gid]
+		n.Status = g.Status(gid)
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// Edges returns subtask edges followed by dependency edges.
+func (g *Graph) Edges() []Edge {
+	var edges []Edge
+	for _, gid := range g.order {
+		n := g.nodes[gid]
+		if n.Parent != "" {
+			if _, ok := g.nodes[n.Parent]; ok {
+				edges = append(edges, Edge{From: n.Parent, To: gid, Type: EdgeSubtask})
+			}
+		}
+	}
+	for _, gid := range g.order {
+		for _, dep := range g.nodes[gid].DependsOn {
+			edges = append(edges, Edge{From: dep, To: gid, Type: EdgeDependency})
+		}
+	}
+	return edges
+}
+
+// Status applies the same rule as the ready and blocked commands: an
+// incomplete task is ready when none of its direct dependencies are
+// incomplete.
+func (g *Graph) Status(gid string) string {
+	n := g.nodes[gid]
+	if n.Completed {
+		return StatusCompleted
+	}
+	for _, dep := range n.DependsOn {
+		if d := g.nodes[dep]; d != nil && !d.Completed {
+			return StatusBlocked
+		}
+	}
+	return StatusReady
+}
+
+// Document is the JSON form of a graph.
+type Document struct {
+	Nodes []*Node `json:"nodes"`
+	Edges []Edge  `json:"edges"`
+}
+
+func (g *Graph) Document() Document {
+	doc := Document{Nodes: g.Nodes(), Edges: g.Edges()}
+	if doc.Edges == nil {
+		doc.Edges = []Edge{}
+	}
+	return doc
+}
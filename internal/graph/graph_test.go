@@ -0,0 +1,144 @@
+package graph
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+func deps(tasks ...models.Task) *[]models.Task {
+	return &tasks
+}
+
+func sampleGraph() *Graph {
+	g := New()
+	g.AddTask(models.Task{GID: "1", Name: "Design", Dependencies: deps()}, "Todo")
+	g.AddTask(models.Task{GID: "2", Name: "Build", Dependencies: deps(models.Task{GID: "1", Name: "Design"})}, "Todo")
+	g.AddTask(models.Task{GID: "3", Name: `Ship "v1"`, DueOn: "2026-01-02", Dependencies: deps(
+		models.Task{GID: "2", Name: "Build"},
+		models.Task{GID: "9", Name: "Legal review", Completed: true},
+	)}, "Release")
+	g.AddTask(models.Task{GID: "4", Name: "Write tests", Parent: &models.AsanaResource{GID: "2"}, Dependencies: deps()}, "Todo")
+	return g
+}
+
+func TestGraph_Status(t *testing.T) {
+	g := sampleGraph()
+
+	want := map[string]string{"1": StatusReady, "2": StatusBlocked, "3": StatusBlocked, "4": StatusReady, "9": StatusCompleted}
+	for gid, status := range want {
+		if got := g.Status(gid); got != status {
+			t.Errorf("Status(%s) = %s, want %s", gid, got, status)
+		}
+	}
+
+	if !g.Node("9").External {
+		t.Error("dependency outside the set should be external")
+	}
+
+	g.AddTask(models.Task{GID: "1", Name: "Design", Completed: true}, "Done")
+	if got := g.Status("2"); got != StatusReady {
+		t.Errorf("Status(2) after completing its dependency = %s, want ready", got)
+	}
+}
+
+func TestGraph_ExternalUpgradedWhenAdded(t *testing.T) {
+	g := New()
+	g.AddTask(models.Task{GID: "2", Dependencies: deps(models.Task{GID: "1", Name: "Dep"})}, "")
+	g.AddTask(models.Task{GID: "1", Name: "Dep", Dependencies: deps()}, "Todo")
+
+	n := g.Node("1")
+	if n.External || n.Section != "Todo" {
+		t.Errorf("node = %+v, want upgraded to a full node", n)
+	}
+	if len(g.Nodes()) != 2 {
+		t.Errorf("got %d nodes, want 2", len(g.Nodes()))
+	}
+}
+
+func TestGraph_Edges(t *testing.T) {
+	got := sampleGraph().Edges()
+	want := []Edge{
+		{From: "2", To: "4", Type: EdgeSubtask},
+		{From: "1", To: "2", Type: EdgeDependency},
+		{From: "2", To: "3", Type: EdgeDependency},
+		{From: "9", To: "3", Type: EdgeDependency},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Edges() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGraph_WriteMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleGraph().WriteMermaid(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"flowchart LR\n",
+		`subgraph s0["Todo"]`,
+		`t3["Ship #quot;v1#quot; (due 2026-01-02)"]`,
+		"t1 --> t2",
+		"t2 -.- t4",
+		"class t2,t3 blocked",
+		"class t9 external",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("mermaid output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGraph_WriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleGraph().WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"digraph asana {",
+		`label="Release";`,
+		`"3" [label="Ship \"v1\" (due 2026-01-02)", fillcolor="#f4a6a6"];`,
+		`"9" [label="Legal review", fillcolor="#d9d9d9", style="rounded,filled,dashed"];`,
+		`"1" -> "2";`,
+		`"2" -> "4" [style=dashed, arrowhead=none];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dot output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGraph_PathAndCycles(t *testing.T) {
+	g := New()
+	// 1 -> 2 -> 3 -> 1 is a cycle; 4 depends on it; 5 depends on itself.
+	g.SetDependencies("1", []models.Task{{GID: "2"}})
+	g.SetDependencies("2", []models.Task{{GID: "3"}})
+	g.SetDependencies("3", []models.Task{{GID: "1"}})
+	g.SetDependencies("4", []models.Task{{GID: "1"}, {GID: "6"}})
+	g.SetDependencies("5", []models.Task{{GID: "5"}})
+
+	if got := g.Path("4", "3"); !reflect.DeepEqual(got, []string{"4", "1", "2", "3"}) {
+		t.Errorf("Path(4, 3) = %v", got)
+	}
+	if got := g.Path("1", "4"); got != nil {
+		t.Errorf("Path(1, 4) = %v, want nil", got)
+	}
+
+	want := [][]string{{"1", "2", "3", "1"}, {"5", "5"}}
+	if got := g.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Cycles() = %v, want %v", got, want)
+	}
+}
+
+func TestGraph_NoCycles(t *testing.T) {
+	if got := sampleGraph().Cycles(); got != nil {
+		t.Errorf("Cycles() = %v, want none", got)
+	}
+}
@@ -0,0 +1,148 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var statusColors = map[string]string{
+	StatusCompleted: "#d9d9d9",
+	StatusReady:     "#b7e1a1",
+	StatusBlocked:   "#f4a6a6",
+}
+
+// sectionGroups returns section names in first-seen order and the nodes in
+// each. Nodes with no section are keyed by "".
+func sectionGroups(nodes []*Node) ([]string, map[string][]*Node) {
+	var order []string
+	groups := map[string][]*Node{}
+	for _, n := range nodes {
+		if _, ok := groups[n.Section]; !ok {
+			order = append(order, n.Section)
+		}
+		groups[n.Section] = append(groups[n.Section], n)
+	}
+	return order, groups
+}
+
+func label(n *Node) string {
+	name := n.Name
+	if name == "" {
+		name = n.GID
+	}
+	if n.DueOn != "" && !n.Completed {
+		name += " (due " + n.DueOn + ")"
+	}
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// WriteDOT renders the graph for Graphviz. Sections become clusters, nodes
+// are filled by status, and subtask edges are dashed.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph asana {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	order, groups := sectionGroups(g.Nodes())
+	cluster := 0
+	for _, section := range order {
+		indent := "  "
+		if section != "" {
+			fmt.Fprintf(&b, "  subgraph cluster_%d {\n    label=%s;\n", cluster, dotQuote(section))
+			indent = "    "
+			cluster++
+		}
+		for _, n := range groups[section] {
+			style := ""
+			if n.External {
+				style = `, style="rounded,filled,dashed"`
+			}
+			fmt.Fprintf(&b, "%s%s [label=%s, fillcolor=%s%s];\n", indent, dotQuote(n.GID), dotQuote(label(n)), dotQuote(statusColors[n.Status]), style)
+		}
+		if section != "" {
+			b.WriteString("  }\n")
+		}
+	}
+
+	for _, e := range g.Edges() {
+		attrs := ""
+		if e.Type == EdgeSubtask {
+			attrs = " [style=dashed, arrowhead=none]"
+		}
+		fmt.Fprintf(&b, "  %s -> %s%s;\n", dotQuote(e.From), dotQuote(e.To), attrs)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// WriteMermaid renders a flowchart that GitHub and most doc tools display
+// inline. Sections become subgraphs and nodes are classed by status.
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	nodes := g.Nodes()
+	order, groups := sectionGroups(nodes)
+	for i, section := range order {
+		indent := "  "
+		if section != "" {
+			fmt.Fprintf(&b, "  subgraph s%d[%s]\n", i, mermaidQuote(section))
+			indent = "    "
+		}
+		for _, n := range groups[section] {
+			fmt.Fprintf(&b, "%s%s[%s]\n", indent, mermaidID(n.GID), mermaidQuote(label(n)))
+		}
+		if section != "" {
+			b.WriteString("  end\n")
+		}
+	}
+
+	for _, e := range g.Edges() {
+		arrow := "-->"
+		if e.Type == EdgeSubtask {
+			arrow = "-.-"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", mermaidID(e.From), arrow, mermaidID(e.To))
+	}
+
+	for _, status := range []string{StatusCompleted, StatusReady, StatusBlocked} {
+		fmt.Fprintf(&b, "  classDef %s fill:%s\n", status, statusColors[status])
+	}
+	b.WriteString("  classDef external stroke-dasharray:5 5\n")
+
+	classes := map[string][]string{}
+	for _, n := range nodes {
+		classes[n.Status] = append(classes[n.Status], mermaidID(n.GID))
+		if n.External {
+			classes["external"] = append(classes["external"], mermaidID(n.GID))
+		}
+	}
+	for _, class := range []string{StatusCompleted, StatusReady, StatusBlocked, "external"} {
+		if ids := classes[class]; len(ids) > 0 {
+			fmt.Fprintf(&b, "  class %s %s\n", strings.Join(ids, ","), class)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func mermaidID(gid string) string {
+	return "t" + gid
+}
+
+// mermaidQuote wraps a label in quotes, using Mermaid's entity for embedded
+// quotes since backslash escapes are not supported.
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,265 @@
+// Package mcp implements the server side of the Model Context Protocol over
+// stdio: newline-delimited JSON-RPC 2.0 with the initialize handshake,
+// tools/list and tools/call. Resources and prompts are not supported.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ProtocolVersion is the newest protocol revision this server speaks.
+const ProtocolVersion = "2025-06-18"
+
+var supportedVersions = map[string]bool{
+	"2024-11-05": true,
+	"2025-03-26": true,
+	"2025-06-18": true,
+}
+
+// JSON-RPC error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+)
+
+const maxMessageBytes = 10 << 20
+
+// Schema is the subset of JSON Schema used to describe tool arguments.
+type Schema struct {
+	Type        string             `json:"type"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+}
+
+// Handler runs a tool call. Strings are returned to the client as-is; any
+// other value is encoded as JSON. A returned error becomes a tool result
+// with isError set, so the model sees the failure rather than the transport.
+type Handler func(ctx context.Context, args json.RawMessage) (any, error)
+
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema *Schema
+	Handler     Handler
+}
+
+type Server struct {
+	name    string
+	version string
+	tools   []Tool
+	byName  map[string]int
+
+	// FormatError renders a tool error for the client. It defaults to
+	// err.Error().
+	FormatError func(err error) string
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewServer(name, version string) *Server {
+	return &Server{name: name, version: version, byName: map[string]int{}}
+}
+
+func (s *Server) AddTool(t Tool) {
+	s.byName[t.Name] = len(s.tools)
+	s.tools = append(s.tools, t)
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type toolResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// Serve reads requests from r and writes responses to w until r is closed
+// or ctx is cancelled. Requests are handled one at a time in arrival order.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.w = w
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageBytes)
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return nil
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if err := s.handle(ctx, line); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading requests: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) handle(ctx context.Context, line []byte) error {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return s.write(response{ID: json.RawMessage("null"), Error: &rpcError{Code: CodeParseError, Message: "parse error"}})
+	}
+
+	// Notifications (no id) never get a response.
+	if len(req.ID) == 0 {
+		return nil
+	}
+
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return s.write(response{ID: req.ID, Error: &rpcError{Code: CodeInvalidRequest, Message: "invalid request"}})
+	}
+
+	result, rpcErr := s.dispatch(ctx, req)
+	return s.write(response{ID: req.ID, Result: result, Error: rpcErr})
+}
+
+func (s *Server) dispatch(ctx context.Context, req request) (any, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		return s.initialize(req.Params)
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.listTools(), nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	default:
+		return nil, &rpcError{Code: CodeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+func (s *Server) initialize(params json.RawMessage) (any, *rpcError) {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &rpcError{Code: CodeInvalidParams, Message: "invalid initialize params"}
+		}
+	}
+
+	version := ProtocolVersion
+	if supportedVersions[p.ProtocolVersion] {
+		version = p.ProtocolVersion
+	}
+
+	return map[string]any{
+		"protocolVersion": version,
+		"capabilities": map[string]any{
+			"tools": map[string]any{"listChanged": false},
+		},
+		"serverInfo": map[string]any{"name": s.name, "version": s.version},
+	}, nil
+}
+
+func (s *Server) listTools() any {
+	tools := make([]map[string]any, 0, len(s.tools))
+	for _, t := range s.tools {
+		schema := t.InputSchema
+		if schema == nil {
+			schema = &Schema{Type: "object"}
+		}
+		if schema.Properties == nil {
+			schema.Properties = map[string]*Schema{}
+		}
+		tools = append(tools, map[string]any{
+			"name":        t.Name,
+			"description": t.Description,
+			"inputSchema": schema,
+		})
+	}
+	return map[string]any{"tools": tools}
+}
+
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (any, *rpcError) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{Code: CodeInvalidParams, Message: "invalid tools/call params"}
+	}
+
+	i, ok := s.byName[p.Name]
+	if !ok {
+		return nil, &rpcError{Code: CodeInvalidParams, Message: "unknown tool: " + p.Name}
+	}
+	tool := s.tools[i]
+
+	args := p.Arguments
+	if len(args) == 0 || string(args) == "null" {
+		args = json.RawMessage("{}")
+	}
+
+	value, err := tool.Handler(ctx, args)
+	if err != nil {
+		return toolResult{Content: []content{{Type: "text", Text: s.formatError(err)}}, IsError: true}, nil
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return toolResult{Content: []content{{Type: "text", Text: s.formatError(err)}}, IsError: true}, nil
+		}
+		text = string(data)
+	}
+	return toolResult{Content: []content{{Type: "text", Text: text}}}, nil
+}
+
+func (s *Server) formatError(err error) string {
+	if s.FormatError != nil {
+		return s.FormatError(err)
+	}
+	return err.Error()
+}
+
+func (s *Server) write(resp response) error {
+	resp.JSONRPC = "2.0"
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,172 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+func serve(t *testing.T, s *Server, lines ...string) []testResponse {
+	t.Helper()
+	var out bytes.Buffer
+	if err := s.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	var responses []testResponse
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var r testResponse
+		if err := dec.Decode(&r); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		responses = append(responses, r)
+	}
+	return responses
+}
+
+func newTestServer() *Server {
+	s := NewServer("test", "1.0")
+	s.AddTool(Tool{
+		Name:        "echo",
+		Description: "Echo the message",
+		InputSchema: &Schema{Type: "object", Properties: map[string]*Schema{"message": {Type: "string"}}, Required: []string{"message"}},
+		Handler: func(_ context.Context, args json.RawMessage) (any, error) {
+			var a struct {
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(args, &a); err != nil {
+				return nil, err
+			}
+			if a.Message == "" {
+				return nil, errors.New("message is required")
+			}
+			return map[string]string{"message": a.Message}, nil
+		},
+	})
+	return s
+}
+
+func TestServer_Initialize(t *testing.T) {
+	responses := serve(t, newTestServer(),
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{},"clientInfo":{"name":"c","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`,
+	)
+
+	if len(responses) != 2 {
+		t.Fatalf("got %d responses, want 2 (notifications get none)", len(responses))
+	}
+
+	var result struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ServerInfo      struct {
+			Name string `json:"name"`
+		} `json:"serverInfo"`
+		Capabilities map[string]any `json:"capabilities"`
+	}
+	if err := json.Unmarshal(responses[0].Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.ProtocolVersion != "2024-11-05" {
+		t.Errorf("protocolVersion = %q, want client's supported version", result.ProtocolVersion)
+	}
+	if result.ServerInfo.Name != "test" {
+		t.Errorf("serverInfo.name = %q", result.ServerInfo.Name)
+	}
+	if _, ok := result.Capabilities["tools"]; !ok {
+		t.Error("missing tools capability")
+	}
+
+	if err := json.Unmarshal(responses[1].Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.ProtocolVersion != ProtocolVersion {
+		t.Errorf("unsupported client version: got %q, want %q", result.ProtocolVersion, ProtocolVersion)
+	}
+}
+
+func TestServer_ListTools(t *testing.T) {
+	responses := serve(t, newTestServer(), `{"jsonrpc":"2.0","id":"a","method":"tools/list"}`)
+
+	if string(responses[0].ID) != `"a"` {
+		t.Errorf("id = %s, want \"a\"", responses[0].ID)
+	}
+
+	var result struct {
+		Tools []struct {
+			Name        string `json:"name"`
+			InputSchema Schema `json:"inputSchema"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(responses[0].Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Tools) != 1 || result.Tools[0].Name != "echo" {
+		t.Fatalf("tools = %+v", result.Tools)
+	}
+	if result.Tools[0].InputSchema.Required[0] != "message" {
+		t.Errorf("schema = %+v", result.Tools[0].InputSchema)
+	}
+}
+
+func TestServer_CallTool(t *testing.T) {
+	s := newTestServer()
+	s.FormatError = func(err error) string { return "formatted: " + err.Error() }
+
+	responses := serve(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{"message":"hi"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"nope"}}`,
+	)
+
+	var result toolResult
+	if err := json.Unmarshal(responses[0].Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.IsError || !strings.Contains(result.Content[0].Text, `"message": "hi"`) {
+		t.Errorf("result = %+v", result)
+	}
+
+	result = toolResult{}
+	if err := json.Unmarshal(responses[1].Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsError || result.Content[0].Text != "formatted: message is required" {
+		t.Errorf("error result = %+v", result)
+	}
+
+	if responses[2].Error == nil || responses[2].Error.Code != CodeInvalidParams {
+		t.Errorf("unknown tool error = %+v", responses[2].Error)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	responses := serve(t, newTestServer(),
+		`not json`,
+		`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"ping"}`,
+	)
+
+	if len(responses) != 3 {
+		t.Fatalf("got %d responses, want 3", len(responses))
+	}
+	if responses[0].Error == nil || responses[0].Error.Code != CodeParseError {
+		t.Errorf("parse error = %+v", responses[0].Error)
+	}
+	if responses[1].Error == nil || responses[1].Error.Code != CodeMethodNotFound {
+		t.Errorf("method not found = %+v", responses[1].Error)
+	}
+	if responses[2].Error != nil || string(responses[2].Result) != "{}" {
+		t.Errorf("ping = %s %+v", responses[2].Result, responses[2].Error)
+	}
+}
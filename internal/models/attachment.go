@@ -0,0 +1,14 @@
+package models
+
+type Attachment struct {
+	GID             string         `json:"gid"`
+	Name            string         `json:"name"`
+	ResourceSubtype string         `json:"resource_subtype,omitempty"`
+	CreatedAt       string         `json:"created_at,omitempty"`
+	DownloadURL     string         `json:"download_url,omitempty"`
+	PermanentURL    string         `json:"permanent_url,omitempty"`
+	ViewURL         string         `json:"view_url,omitempty"`
+	Host            string         `json:"host,omitempty"`
+	Size            int64          `json:"size,omitempty"`
+	Parent          *AsanaResource `json:"parent,omitempty"`
+}
@@ -14,3 +14,11 @@ type ListResponse[T any] struct {
 	Data     []T       `json:"data"`
 	NextPage *PageInfo `json:"next_page,omitempty"`
 }
+
+func (l *ListResponse[T]) Items() []any {
+	items := make([]any, len(l.Data))
+	for i, item := range l.Data {
+		items[i] = item
+	}
+	return items
+}
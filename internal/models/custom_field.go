@@ -0,0 +1,35 @@
+package models
+
+type CustomField struct {
+	GID             string          `json:"gid"`
+	Name            string          `json:"name"`
+	ResourceSubtype string          `json:"resource_subtype,omitempty"`
+	Description     string          `json:"description,omitempty"`
+	Precision       *int            `json:"precision,omitempty"`
+	EnumOptions     []EnumOption    `json:"enum_options,omitempty"`
+	DisplayValue    *string         `json:"display_value,omitempty"`
+	TextValue       *string         `json:"text_value,omitempty"`
+	NumberValue     *float64        `json:"number_value,omitempty"`
+	EnumValue       *EnumOption     `json:"enum_value,omitempty"`
+	MultiEnumValues []EnumOption    `json:"multi_enum_values,omitempty"`
+	DateValue       *DateValue      `json:"date_value,omitempty"`
+	PeopleValue     []AsanaResource `json:"people_value,omitempty"`
+}
+
+type EnumOption struct {
+	GID     string `json:"gid"`
+	Name    string `json:"name"`
+	Color   string `json:"color,omitempty"`
+	Enabled *bool  `json:"enabled,omitempty"`
+}
+
+type DateValue struct {
+	Date     string `json:"date,omitempty"`
+	DateTime string `json:"date_time,omitempty"`
+}
+
+type CustomFieldSetting struct {
+	GID         string      `json:"gid"`
+	IsImportant bool        `json:"is_important"`
+	CustomField CustomField `json:"custom_field"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "encoding/json"
+
+type Event struct {
+	Action    string         `json:"action"`
+	CreatedAt string         `json:"created_at,omitempty"`
+	User      *AsanaResource `json:"user,omitempty"`
+	Resource  *EventResource `json:"resource,omitempty"`
+	Parent    *EventResource `json:"parent,omitempty"`
+	Change    *EventChange   `json:"change,omitempty"`
+}
+
+type EventResource struct {
+	GID             string `json:"gid"`
+	ResourceType    string `json:"resource_type,omitempty"`
+	ResourceSubtype string `json:"resource_subtype,omitempty"`
+	Name            string `json:"name,omitempty"`
+}
+
+type EventChange struct {
+	Field        string          `json:"field"`
+	Action       string          `json:"action"`
+	NewValue     json.RawMessage `json:"new_value,omitempty"`
+	AddedValue   json.RawMessage `json:"added_value,omitempty"`
+	RemovedValue json.RawMessage `json:"removed_value,omitempty"`
+}
@@ -12,6 +12,25 @@ type Task struct {
 	Parent       *AsanaResource  `json:"parent,omitempty"`
 	Tags         []AsanaResource `json:"tags,omitempty"`
 	Dependencies *[]Task         `json:"dependencies,omitempty"`
+	CustomFields []CustomField   `json:"custom_fields,omitempty"`
+	Memberships  []Membership    `json:"memberships,omitempty"`
+	NumSubtasks  int             `json:"num_subtasks,omitempty"`
+}
+
+// Membership places a task in a project and, optionally, a section of it.
+type Membership struct {
+	Project *AsanaResource `json:"project,omitempty"`
+	Section *AsanaResource `json:"section,omitempty"`
+}
+
+// SectionIn returns the task's section within project, if it has one.
+func (t Task) SectionIn(project string) *AsanaResource {
+	for _, m := range t.Memberships {
+		if m.Project != nil && m.Project.GID == project {
+			return m.Section
+		}
+	}
+	return nil
 }
 
 func (t Task) GetName() string { return t.Name }
@@ -25,6 +44,8 @@ type TaskCreateRequest struct {
 	Projects  []string `json:"projects,omitempty"`
 	Parent    string   `json:"parent,omitempty"`
 	Workspace string   `json:"workspace,omitempty"`
+
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 }
 
 type TaskUpdateRequest struct {
@@ -33,4 +54,6 @@ type TaskUpdateRequest struct {
 	Assignee  *string `json:"assignee,omitempty"`
 	DueOn     *string `json:"due_on,omitempty"`
 	Completed *bool   `json:"completed,omitempty"`
+
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
 }
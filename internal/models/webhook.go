@@ -0,0 +1,26 @@
+package models
+
+type Webhook struct {
+	GID                string          `json:"gid"`
+	Active             bool            `json:"active"`
+	Resource           *EventResource  `json:"resource,omitempty"`
+	Target             string          `json:"target"`
+	CreatedAt          string          `json:"created_at,omitempty"`
+	LastSuccessAt      string          `json:"last_success_at,omitempty"`
+	LastFailureAt      string          `json:"last_failure_at,omitempty"`
+	LastFailureContent string          `json:"last_failure_content,omitempty"`
+	Filters            []WebhookFilter `json:"filters,omitempty"`
+}
+
+type WebhookFilter struct {
+	ResourceType    string   `json:"resource_type,omitempty"`
+	ResourceSubtype string   `json:"resource_subtype,omitempty"`
+	Action          string   `json:"action,omitempty"`
+	Fields          []string `json:"fields,omitempty"`
+}
+
+type WebhookCreateRequest struct {
+	Resource string          `json:"resource"`
+	Target   string          `json:"target"`
+	Filters  []WebhookFilter `json:"filters,omitempty"`
+}
@@ -15,8 +15,11 @@ type Formatter interface {
 }
 
 func NewFormatter(format string, w io.Writer) Formatter {
-	if format == "brief" {
+	switch format {
+	case "brief":
 		return &Brief{w: w}
+	case "ndjson":
+		return NewNDJSON(w)
 	}
 	return NewJSON(w)
 }
@@ -32,6 +35,9 @@ func (b *Brief) Print(v any) error {
 	if task, ok := v.(*models.Task); ok && task != nil {
 		return b.printTask(*task)
 	}
+	if list, ok := v.(*models.ListResponse[models.Task]); ok && list != nil {
+		return b.PrintTaskList(list)
+	}
 	json := NewJSON(b.w)
 	return json.Print(v)
 }
@@ -29,6 +29,7 @@ type ErrorDetail struct {
 	Message  string `json:"message"`
 	Code     string `json:"code"`
 	ExitCode int    `json:"exit_code"`
+	Details  any    `json:"details,omitempty"`
 }
 
 func (j *JSON) PrintError(err error) error {
@@ -39,6 +40,7 @@ func (j *JSON) PrintError(err error) error {
 			Message:  cliErr.Message,
 			Code:     cliErr.Code,
 			ExitCode: cliErr.ExitCode,
+			Details:  cliErr.Details,
 		},
 	}
 
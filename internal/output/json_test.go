@@ -82,3 +82,30 @@ func TestPrintError(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintErrorDetails(t *testing.T) {
+	var buf bytes.Buffer
+	err := errors.NewDependencyCycleError("cycle", []string{"1", "2", "1"})
+	if perr := NewJSON(&buf).PrintError(err); perr != nil {
+		t.Fatal(perr)
+	}
+
+	var result struct {
+		Error struct {
+			Code     string `json:"code"`
+			ExitCode int    `json:"exit_code"`
+			Details  struct {
+				Cycle []string `json:"cycle"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Error.Code != "DEPENDENCY_CYCLE" || result.Error.ExitCode != errors.ExitInvalidArgs {
+		t.Errorf("error = %+v", result.Error)
+	}
+	if len(result.Error.Details.Cycle) != 3 {
+		t.Errorf("details.cycle = %v", result.Error.Details.Cycle)
+	}
+}
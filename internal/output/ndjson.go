@@ -0,0 +1,66 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+// Streamer is implemented by formatters that can write list items one at a
+// time as pages arrive, rather than buffering a whole ListResponse.
+type Streamer interface {
+	WriteItem(v any) error
+}
+
+// NDJSON writes one compact JSON value per line. Lists are flattened so each
+// item gets its own line; pagination metadata is not emitted.
+type NDJSON struct {
+	enc *json.Encoder
+}
+
+func NewNDJSON(w io.Writer) *NDJSON {
+	return &NDJSON{enc: json.NewEncoder(w)}
+}
+
+func (n *NDJSON) WriteItem(v any) error {
+	return n.enc.Encode(v)
+}
+
+func (n *NDJSON) Print(v any) error {
+	if items, ok := v.(interface{ Items() []any }); ok {
+		for _, item := range items.Items() {
+			if err := n.WriteItem(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return n.WriteItem(v)
+}
+
+func (n *NDJSON) PrintError(err error) error {
+	cliErr := errors.AsCLIError(err)
+	return n.WriteItem(ErrorResponse{
+		Error: ErrorDetail{
+			Message:  cliErr.Message,
+			Code:     cliErr.Code,
+			ExitCode: cliErr.ExitCode,
+			Details:  cliErr.Details,
+		},
+	})
+}
+
+func (n *NDJSON) PrintTasks(tasks []models.Task) error {
+	for _, t := range tasks {
+		if err := n.WriteItem(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *NDJSON) PrintTaskList(list *models.ListResponse[models.Task]) error {
+	return n.PrintTasks(list.Data)
+}
@@ -0,0 +1,87 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/whoaa512/asana-cli/internal/errors"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+func TestNewFormatterNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if _, ok := NewFormatter("ndjson", &buf).(Streamer); !ok {
+		t.Error("ndjson formatter should implement Streamer")
+	}
+	if _, ok := NewFormatter("json", &buf).(Streamer); ok {
+		t.Error("json formatter should not implement Streamer")
+	}
+}
+
+func TestNDJSONPrintList(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewNDJSON(&buf)
+
+	list := &models.ListResponse[models.Task]{
+		Data: []models.Task{
+			{GID: "1", Name: "First"},
+			{GID: "2", Name: "Second"},
+		},
+		NextPage: &models.PageInfo{Offset: "abc"},
+	}
+
+	if err := out.Print(list); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+
+	for i, want := range []string{"1", "2"} {
+		var task models.Task
+		if err := json.Unmarshal([]byte(lines[i]), &task); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", i, err)
+		}
+		if task.GID != want {
+			t.Errorf("line %d gid = %q, want %q", i, task.GID, want)
+		}
+	}
+}
+
+func TestNDJSONPrintObject(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewNDJSON(&buf)
+
+	if err := out.Print(map[string]any{"gid": "123"}); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+
+	if got := buf.String(); got != "{\"gid\":\"123\"}\n" {
+		t.Errorf("output = %q, want compact single line", got)
+	}
+}
+
+func TestNDJSONPrintError(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewNDJSON(&buf)
+
+	if err := out.PrintError(errors.NewNotFoundError("task")); err != nil {
+		t.Fatalf("PrintError() error = %v", err)
+	}
+
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("error should be a single line, got %q", buf.String())
+	}
+
+	var result ErrorResponse
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Error.Code != "NOT_FOUND" {
+		t.Errorf("code = %q, want NOT_FOUND", result.Error.Code)
+	}
+}
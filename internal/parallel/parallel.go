@@ -0,0 +1,72 @@
+// Package parallel runs a bounded number of calls concurrently.
+package parallel
+
+import (
+	"context"
+	"sync"
+)
+
+// Each calls fn for every index in [0, n) on at most workers goroutines.
+// The first error cancels the context passed to the remaining calls, stops
+// new calls from starting, and is returned once the started ones finish.
+// Callers keep output deterministic by writing results into slot i.
+func Each(ctx context.Context, n, workers int, fn func(ctx context.Context, i int) error) error {
+	if n == 0 {
+		return ctx.Err()
+	}
+	if workers <= 0 || workers > n {
+		workers = n
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		once     sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	indexes := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				// The feed may hand out an index in the same instant the
+				// context is cancelled; don't start it.
+				if runCtx.Err() != nil {
+					continue
+				}
+				if err := fn(runCtx, i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case indexes <- i:
+		case <-runCtx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// Do runs every fn concurrently, with the same cancellation as Each.
+func Do(ctx context.Context, fns ...func(ctx context.Context) error) error {
+	return Each(ctx, len(fns), len(fns), func(ctx context.Context, i int) error {
+		return fns[i](ctx)
+	})
+}
@@ -0,0 +1,87 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEach_ResultsBySlot(t *testing.T) {
+	out := make([]int, 50)
+	err := Each(context.Background(), len(out), 4, func(_ context.Context, i int) error {
+		time.Sleep(time.Duration(50-i) * 10 * time.Microsecond)
+		out[i] = i * i
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Each() error = %v", err)
+	}
+	for i, v := range out {
+		if v != i*i {
+			t.Fatalf("out[%d] = %d, want %d", i, v, i*i)
+		}
+	}
+}
+
+func TestEach_BoundsWorkers(t *testing.T) {
+	var running, peak atomic.Int32
+	err := Each(context.Background(), 20, 3, func(_ context.Context, _ int) error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := peak.Load(); got > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", got)
+	}
+}
+
+func TestEach_FirstErrorCancels(t *testing.T) {
+	boom := errors.New("boom")
+	var started atomic.Int32
+	err := Each(context.Background(), 100, 2, func(ctx context.Context, i int) error {
+		started.Add(1)
+		if i == 0 {
+			return boom
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("Each() error = %v, want boom", err)
+	}
+	if n := started.Load(); n > 3 {
+		t.Errorf("%d calls started after the error, want new calls stopped", n)
+	}
+}
+
+func TestEach_ParentCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := Each(ctx, 5, 2, func(ctx context.Context, _ int) error { return nil })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Each() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestDo(t *testing.T) {
+	var a, b int
+	err := Do(context.Background(),
+		func(context.Context) error { a = 1; return nil },
+		func(context.Context) error { b = 2; return nil },
+	)
+	if err != nil || a != 1 || b != 2 {
+		t.Errorf("Do() = %v, a=%d b=%d", err, a, b)
+	}
+}
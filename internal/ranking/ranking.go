@@ -0,0 +1,184 @@
+// Package ranking scores ready tasks so the most useful one to pick up next
+// comes first.
+package ranking
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+const (
+	FactorDue      = "due"
+	FactorUnblocks = "unblocks"
+	FactorPosition = "position"
+	FactorTags     = "tags"
+	FactorAssignee = "assignee"
+)
+
+// Factors lists every factor in the order they are reported.
+var Factors = []string{FactorDue, FactorUnblocks, FactorPosition, FactorTags, FactorAssignee}
+
+// DefaultWeights favour deadlines, then unblocking other work.
+var DefaultWeights = map[string]float64{
+	FactorDue:      3,
+	FactorUnblocks: 2,
+	FactorPosition: 1,
+	FactorTags:     1,
+	FactorAssignee: 1,
+}
+
+// Model scores tasks for one user on one day.
+type Model struct {
+	Weights map[string]float64
+	Tags    map[string]float64
+	Me      string
+	Today   time.Time
+}
+
+// NewModel applies the weights in cfg over DefaultWeights. me is the current
+// user's GID, used by the assignee factor.
+func NewModel(cfg *config.Scoring, me string, today time.Time) (*Model, error) {
+	m := &Model{Weights: map[string]float64{}, Tags: map[string]float64{}, Me: me, Today: today}
+	for k, v := range DefaultWeights {
+		m.Weights[k] = v
+	}
+	if cfg == nil {
+		return m, nil
+	}
+	for k, v := range cfg.Weights {
+		if _, ok := DefaultWeights[k]; !ok {
+			return nil, fmt.Errorf("unknown scoring weight %q (valid: %s)", k, strings.Join(Factors, ", "))
+		}
+		m.Weights[k] = v
+	}
+	for k, v := range cfg.Tags {
+		m.Tags[strings.ToLower(k)] = v
+	}
+	return m, nil
+}
+
+// Candidate is a ready task with the context needed to score it.
+type Candidate struct {
+	Task models.Task
+	// Dependents is the number of incomplete tasks waiting on this one.
+	Dependents int
+	// Position is the index of the task's section in the project, out of
+	// Total sections.
+	Position int
+	Total    int
+}
+
+// Factor is one term of a score: Score is Value times Weight.
+type Factor struct {
+	Value  float64 `json:"value"`
+	Weight float64 `json:"weight"`
+	Score  float64 `json:"score"`
+}
+
+type Result struct {
+	Candidate
+	Score     float64
+	Breakdown map[string]Factor
+}
+
+// Score returns the weighted sum of every factor and the breakdown behind it.
+// Each value is in [0, 1] except tags, which is the sum of the matching tag
+// weights.
+func (m *Model) Score(c Candidate) (float64, map[string]Factor) {
+	values := map[string]float64{
+		FactorDue:      m.due(c.Task.DueOn),
+		FactorUnblocks: float64(c.Dependents) / float64(c.Dependents+1),
+		FactorPosition: position(c.Position, c.Total),
+		FactorTags:     m.tags(c.Task.Tags),
+		FactorAssignee: m.assignee(c.Task.Assignee),
+	}
+
+	var total float64
+	breakdown := make(map[string]Factor, len(values))
+	for _, name := range Factors {
+		f := Factor{Value: round(values[name]), Weight: m.Weights[name]}
+		f.Score = round(values[name] * f.Weight)
+		breakdown[name] = f
+		total += values[name] * f.Weight
+	}
+	return round(total), breakdown
+}
+
+// Rank scores every candidate and sorts them best first. Ties keep project
+// order.
+func (m *Model) Rank(candidates []Candidate) []Result {
+	results := make([]Result, len(candidates))
+	for i, c := range candidates {
+		score, breakdown := m.Score(c)
+		results[i] = Result{Candidate: c, Score: score, Breakdown: breakdown}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// due is 1 for tasks due today or overdue, decaying by half a week out, and 0
+// without a due date.
+func (m *Model) due(dueOn string) float64 {
+	if dueOn == "" {
+		return 0
+	}
+	due, err := time.Parse("2006-01-02", dueOn)
+	if err != nil {
+		return 0
+	}
+	today := time.Date(m.Today.Year(), m.Today.Month(), m.Today.Day(), 0, 0, 0, 0, time.UTC)
+	days := due.Sub(today).Hours() / 24
+	if days <= 0 {
+		return 1
+	}
+	return 1 / (1 + days/7)
+}
+
+// position is 1 for the first of total sections, falling towards 0 for the
+// last, and 0 for an index past the end (a task outside every section).
+func position(index, total int) float64 {
+	if index >= total {
+		return 0
+	}
+	if total <= 1 {
+		return 1
+	}
+	return 1 - float64(index)/float64(total)
+}
+
+func (m *Model) tags(tags []models.AsanaResource) float64 {
+	var sum float64
+	for _, t := range tags {
+		if v, ok := m.Tags[t.GID]; ok {
+			sum += v
+		} else if v, ok := m.Tags[strings.ToLower(t.Name)]; ok {
+			sum += v
+		}
+	}
+	return sum
+}
+
+// assignee prefers the current user's tasks, then unassigned ones that are
+// free to pick up.
+func (m *Model) assignee(a *models.AsanaResource) float64 {
+	switch {
+	case a == nil:
+		return 0.5
+	case m.Me != "" && a.GID == m.Me:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,109 @@
+package ranking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/whoaa512/asana-cli/internal/config"
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+var today = time.Date(2026, 3, 10, 15, 0, 0, 0, time.Local)
+
+func TestModel_Score(t *testing.T) {
+	m, err := NewModel(&config.Scoring{Tags: map[string]float64{"Urgent": 2, "99": -1}}, "me", today)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	score, breakdown := m.Score(Candidate{
+		Task: models.Task{
+			DueOn:    "2026-03-17",
+			Assignee: &models.AsanaResource{GID: "me"},
+			Tags:     []models.AsanaResource{{GID: "1", Name: "urgent"}, {GID: "99", Name: "Someday"}},
+		},
+		Dependents: 3,
+		Position:   1,
+		Total:      4,
+	})
+
+	want := map[string]Factor{
+		FactorDue:      {Value: 0.5, Weight: 3, Score: 1.5},
+		FactorUnblocks: {Value: 0.75, Weight: 2, Score: 1.5},
+		FactorPosition: {Value: 0.75, Weight: 1, Score: 0.75},
+		FactorTags:     {Value: 1, Weight: 1, Score: 1},
+		FactorAssignee: {Value: 1, Weight: 1, Score: 1},
+	}
+	for name, f := range want {
+		if breakdown[name] != f {
+			t.Errorf("%s = %+v, want %+v", name, breakdown[name], f)
+		}
+	}
+	if score != 5.75 {
+		t.Errorf("score = %v, want 5.75", score)
+	}
+}
+
+func TestModel_Due(t *testing.T) {
+	m, _ := NewModel(nil, "", today)
+	for due, want := range map[string]float64{
+		"":           0,
+		"not-a-date": 0,
+		"2026-03-01": 1,
+		"2026-03-10": 1,
+		"2026-03-24": 1.0 / 3,
+	} {
+		if got := m.due(due); got != want {
+			t.Errorf("due(%q) = %v, want %v", due, got, want)
+		}
+	}
+}
+
+func TestPosition(t *testing.T) {
+	tests := []struct {
+		index, total int
+		want         float64
+	}{
+		{0, 4, 1},
+		{2, 4, 0.5},
+		{4, 4, 0},
+		{0, 1, 1},
+		{1, 1, 0},
+		{0, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := position(tt.index, tt.total); got != tt.want {
+			t.Errorf("position(%d, %d) = %v, want %v", tt.index, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestModel_Rank(t *testing.T) {
+	m, err := NewModel(&config.Scoring{Weights: map[string]float64{FactorAssignee: 0}}, "", today)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := m.Rank([]Candidate{
+		{Task: models.Task{GID: "a"}, Position: 0, Total: 3},
+		{Task: models.Task{GID: "b", DueOn: "2026-03-09"}, Position: 1, Total: 3},
+		{Task: models.Task{GID: "c"}, Dependents: 1, Position: 2, Total: 3},
+	})
+
+	var order []string
+	for _, r := range results {
+		order = append(order, r.Task.GID)
+	}
+	if got := order[0] + order[1] + order[2]; got != "bca" {
+		t.Errorf("order = %v, want [b c a]", order)
+	}
+	if results[2].Breakdown[FactorAssignee].Weight != 0 {
+		t.Error("configured weight should override the default")
+	}
+}
+
+func TestNewModel_UnknownWeight(t *testing.T) {
+	if _, err := NewModel(&config.Scoring{Weights: map[string]float64{"priority": 1}}, "", today); err == nil {
+		t.Error("expected an error for an unknown weight")
+	}
+}
@@ -0,0 +1,144 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ArchiveDir holds one file per ended session.
+const ArchiveDir = "archive"
+
+// Record is an ended session as kept in the archive.
+type Record struct {
+	ID string `json:"id"`
+	Session
+	EndedAt       time.Time `json:"ended_at"`
+	EndBranch     string    `json:"end_branch,omitempty"`
+	ActiveSeconds int64     `json:"active_seconds"`
+	// Summary is the text added with 'session end --summary'.
+	Summary string `json:"summary,omitempty"`
+	// StoryGID is the summary comment, empty if nothing was posted.
+	StoryGID string `json:"story_gid,omitempty"`
+}
+
+// End archives rec, filling in its ID and active time, and removes its
+// session from the open ones. A running session is paused at EndedAt first,
+// so its active time stays fixed.
+//
+// rec is usually loaded well before End, with a summary posted in between.
+// Entries logged meanwhile are re-read under the lock and merged in, so
+// they are archived rather than lost.
+func (s *Store) End(rec *Record) error {
+	if err := validID(rec.TaskGID); err != nil {
+		return err
+	}
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	latest, err := s.read(rec.TaskGID)
+	if err != nil {
+		return err
+	}
+	if latest == nil {
+		return fmt.Errorf("session for task %s has already ended", rec.TaskGID)
+	}
+	rec.Logs = mergeLogs(rec.Logs, latest.Logs)
+
+	rec.EndedAt = rec.EndedAt.UTC()
+	if !rec.Paused() {
+		_ = rec.Pause(rec.EndedAt)
+	}
+	rec.ID = rec.EndedAt.Format("20060102-150405") + "-" + rec.TaskGID
+	rec.ActiveSeconds = int64(rec.ActiveDuration(rec.EndedAt).Seconds())
+
+	path := filepath.Join(s.dir, ArchiveDir, rec.ID+".json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return err
+	}
+	rec.path = path
+
+	return s.remove(rec.TaskGID)
+}
+
+// Archived returns the archived session with id, or nil if there is none.
+func (s *Store) Archived(id string) (*Record, error) {
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	return readRecord(filepath.Join(s.dir, ArchiveDir, id+".json"))
+}
+
+// History returns the archived sessions, most recently ended first. A file
+// that can't be read is skipped with a warning on stderr, so one bad record
+// doesn't hide the rest.
+func (s *Store) History() ([]*Record, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, ArchiveDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var records []*Record
+	for _, p := range paths {
+		rec, err := readRecord(p)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: skipping archived session %s: %v\n", filepath.Base(p), err)
+			continue
+		}
+		if rec != nil {
+			records = append(records, rec)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].EndedAt.After(records[j].EndedAt)
+	})
+	return records, nil
+}
+
+func readRecord(path string) (*Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var rec Record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, err
+	}
+	if rec.ID == "" {
+		rec.ID = strings.TrimSuffix(filepath.Base(path), ".json")
+	}
+	rec.path = path
+
+	return &rec, nil
+}
+
+// mergeLogs returns logs with the entries of more it lacks appended in order.
+func mergeLogs(logs, more []LogEntry) []LogEntry {
+	seen := make(map[LogEntry]bool, len(logs))
+	for _, l := range logs {
+		seen[l] = true
+	}
+	for _, l := range more {
+		if !seen[l] {
+			logs = append(logs, l)
+		}
+	}
+	return logs
+}
@@ -0,0 +1,134 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreEndArchivesSession(t *testing.T) {
+	store := NewStore(t.TempDir(), "")
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	sess := New("12345", WithBranch("main"))
+	sess.StartedAt = start
+	sess.AddLog("progress", "Did task A")
+	if err := store.Save(sess); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetCurrent("12345"); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := &Record{Session: *sess, EndedAt: start.Add(90 * time.Minute), EndBranch: "feature", StoryGID: "999"}
+	if err := store.End(rec); err != nil {
+		t.Fatalf("End() error = %v", err)
+	}
+	if rec.ID != "20240101-103000-12345" {
+		t.Errorf("ID = %q, want 20240101-103000-12345", rec.ID)
+	}
+
+	if live, _ := store.Get("12345"); live != nil {
+		t.Error("ended session should no longer be open")
+	}
+	if gid := store.CurrentGID(); gid != "" {
+		t.Errorf("CurrentGID() = %q after end, want empty", gid)
+	}
+
+	got, err := store.Archived(rec.ID)
+	if err != nil || got == nil {
+		t.Fatalf("Archived() = %v, %v", got, err)
+	}
+	if got.TaskGID != "12345" || got.StoryGID != "999" || got.EndBranch != "feature" || len(got.Logs) != 1 {
+		t.Errorf("Archived() = %+v, want the ended session", got)
+	}
+	if got.ActiveSeconds != 90*60 || got.FormatDuration() != "1h 30m" {
+		t.Errorf("active = %ds (%s), want 1h 30m", got.ActiveSeconds, got.FormatDuration())
+	}
+
+	if missing, err := store.Archived("nope"); err != nil || missing != nil {
+		t.Errorf("Archived(nope) = %v, %v, want nil, nil", missing, err)
+	}
+}
+
+func TestStoreHistoryOrder(t *testing.T) {
+	store := NewStore(t.TempDir(), "")
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	for i, gid := range []string{"1", "2", "3"} {
+		sess := New(gid)
+		sess.StartedAt = start
+		if err := store.Save(sess); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.End(&Record{Session: *sess, EndedAt: start.Add(time.Duration(i) * time.Hour)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := store.History()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 || records[0].TaskGID != "3" || records[2].TaskGID != "1" {
+		t.Errorf("History() order = %v, want most recent first", records)
+	}
+}
+
+func TestStoreHistorySkipsCorruptRecords(t *testing.T) {
+	store := NewStore(t.TempDir(), "")
+	sess := New("12345")
+	if err := store.Save(sess); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.End(&Record{Session: *sess, EndedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	bad := filepath.Join(store.dir, ArchiveDir, "20240101-000000-999.json")
+	if err := os.WriteFile(bad, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := store.History()
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(records) != 1 || records[0].TaskGID != "12345" {
+		t.Errorf("History() = %v, want only the readable record", records)
+	}
+}
+
+func TestStoreEndKeepsLateLogs(t *testing.T) {
+	store := NewStore(t.TempDir(), "")
+	sess := New("12345")
+	sess.AddLog("progress", "before")
+	if err := store.Save(sess); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.Get("12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Logged by another process while the summary was being posted.
+	if _, err := store.AppendLog("12345", "progress", "late"); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := &Record{Session: *loaded, EndedAt: time.Now()}
+	if err := store.End(rec); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.Archived(rec.ID)
+	if err != nil || got == nil {
+		t.Fatalf("Archived() = %v, %v", got, err)
+	}
+	if len(got.Logs) != 2 || got.Logs[1].Text != "late" {
+		t.Errorf("archived logs = %+v, want before and late", got.Logs)
+	}
+
+	if err := store.End(&Record{Session: *loaded, EndedAt: time.Now()}); err == nil {
+		t.Error("End() of an ended session should fail")
+	}
+}
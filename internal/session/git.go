@@ -24,6 +24,32 @@ func GetRepoRoot() string {
 	return strings.TrimSpace(string(out))
 }
 
+// GetGitDir returns the git dir of the current worktree, which differs
+// between worktrees of the same repo.
+func GetGitDir() string {
+	cmd := exec.Command("git", "rev-parse", "--absolute-git-dir")
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// GetMainRepoRoot returns the top level of the repo's main worktree, which
+// all its linked worktrees share. It falls back to GetRepoRoot.
+func GetMainRepoRoot() string {
+	cmd := exec.Command("git", "rev-parse", "--git-common-dir")
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	common, err := filepath.Abs(strings.TrimSpace(string(out)))
+	if err != nil || filepath.Base(common) != ".git" {
+		return GetRepoRoot()
+	}
+	return filepath.Dir(common)
+}
+
 func GetRepoName() string {
 	root := GetRepoRoot()
 	if root == "" {
@@ -40,18 +66,120 @@ func GetRepoName() string {
 	return normalizeRepoURL(url)
 }
 
+// normalizeRepoURL reduces a remote URL in any of the forms GitHub, GitLab
+// and Bitbucket hand out (scp-style SSH, ssh:// with a port, HTTPS with a
+// username) to host/path.
 func normalizeRepoURL(url string) string {
+	url = strings.TrimSuffix(url, "/")
 	url = strings.TrimSuffix(url, ".git")
 
-	if strings.HasPrefix(url, "git@") {
-		url = strings.TrimPrefix(url, "git@")
-		url = strings.Replace(url, ":", "/", 1)
+	scheme := ""
+	if i := strings.Index(url, "://"); i >= 0 {
+		scheme, url = url[:i], url[i+3:]
+	}
+
+	if at := strings.Index(url, "@"); at >= 0 && at < strings.Index(url+"/", "/") {
+		url = url[at+1:]
+	}
+
+	host, path, _ := strings.Cut(url, "/")
+	if scheme == "" {
+		// scp-style: host:path
+		host, path, _ = strings.Cut(url, ":")
+	} else if h, _, ok := strings.Cut(host, ":"); ok {
+		// Drop the port from ssh://host:7999/path.
+		host = h
+	}
+	if path == "" {
+		return host
+	}
+
+	return host + "/" + path
+}
+
+// CommitURL returns a link to sha in repo, a URL normalized by
+// normalizeRepoURL, or "" if the host isn't GitHub, GitLab or Bitbucket.
+func CommitURL(repo, sha string) string {
+	host, path, ok := strings.Cut(repo, "/")
+	if !ok || sha == "" {
+		return ""
+	}
+	switch {
+	case host == "github.com":
+		return "https://" + repo + "/commit/" + sha
+	case host == "gitlab.com" || strings.HasPrefix(host, "gitlab."):
+		return "https://" + repo + "/-/commit/" + sha
+	case host == "bitbucket.org":
+		return "https://" + host + "/" + path + "/commits/" + sha
+	}
+	return ""
+}
+
+// Commit is one commit made during a session.
+type Commit struct {
+	SHA     string `json:"sha"`
+	Subject string `json:"subject"`
+}
+
+// GetHeadSHA returns the commit HEAD points at, or "" if there is none yet.
+func GetHeadSHA() string {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// gitIn runs git in dir, or in the working directory if dir is empty.
+func gitIn(dir string, args ...string) *exec.Cmd {
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	return exec.Command("git", args...)
+}
+
+// GetCommits returns the commits in dir reachable from HEAD but not from
+// since, oldest first.
+func GetCommits(dir, since string) []Commit {
+	cmd := gitIn(dir, "log", "--reverse", "--format=%H%x1f%s", since+"..HEAD")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
 	}
 
-	url = strings.TrimPrefix(url, "https://")
-	url = strings.TrimPrefix(url, "http://")
+	var commits []Commit
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		sha, subject, ok := strings.Cut(line, "\x1f")
+		if !ok {
+			continue
+		}
+		commits = append(commits, Commit{SHA: sha, Subject: subject})
+	}
+	return commits
+}
 
-	return url
+// GetDiffStat summarizes the changes in dir between since and HEAD, as in
+// "3 files changed, 10 insertions(+), 2 deletions(-)".
+func GetDiffStat(dir, since string) string {
+	cmd := gitIn(dir, "diff", "--shortstat", since, "HEAD")
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// RecordGitActivity fills in the commits and diffstat since the session
+// started, read from the worktree it started in, which after 'session
+// switch' may not be the current one. It does nothing for sessions started
+// outside a repo or before the first commit.
+func (s *Session) RecordGitActivity() {
+	if s.StartSHA == "" {
+		return
+	}
+	s.Commits = GetCommits(s.Worktree, s.StartSHA)
+	s.DiffStat = GetDiffStat(s.Worktree, s.StartSHA)
 }
 
 func IsInGitRepo() bool {
@@ -1,6 +1,9 @@
 package session
 
-import "testing"
+import (
+	"os/exec"
+	"testing"
+)
 
 func TestNormalizeRepoURL(t *testing.T) {
 	tests := []struct {
@@ -12,6 +15,9 @@ func TestNormalizeRepoURL(t *testing.T) {
 		{"https://github.com/org/repo", "github.com/org/repo"},
 		{"http://github.com/org/repo.git", "github.com/org/repo"},
 		{"git@gitlab.com:user/project.git", "gitlab.com/user/project"},
+		{"ssh://git@gitlab.example.com:2222/group/sub/project.git", "gitlab.example.com/group/sub/project"},
+		{"https://user@bitbucket.org/team/repo.git", "bitbucket.org/team/repo"},
+		{"git@bitbucket.org:team/repo.git", "bitbucket.org/team/repo"},
 	}
 
 	for _, tc := range tests {
@@ -50,3 +56,58 @@ func TestGetRepoRoot(t *testing.T) {
 		t.Error("expected non-empty repo root")
 	}
 }
+
+func TestCommitURL(t *testing.T) {
+	tests := []struct {
+		repo string
+		want string
+	}{
+		{"github.com/org/repo", "https://github.com/org/repo/commit/abc123"},
+		{"gitlab.com/group/sub/project", "https://gitlab.com/group/sub/project/-/commit/abc123"},
+		{"gitlab.example.com/group/project", "https://gitlab.example.com/group/project/-/commit/abc123"},
+		{"bitbucket.org/team/repo", "https://bitbucket.org/team/repo/commits/abc123"},
+		{"git.example.com/org/repo", ""},
+		{"repo", ""},
+	}
+
+	for _, tc := range tests {
+		if got := CommitURL(tc.repo, "abc123"); got != tc.want {
+			t.Errorf("CommitURL(%q) = %q, expected %q", tc.repo, got, tc.want)
+		}
+	}
+}
+
+func TestRecordGitActivity(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("test requires git")
+	}
+	t.Chdir(t.TempDir())
+
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "before")
+
+	sess := New("12345", WithStartSHA(GetHeadSHA()))
+	git("commit", "-q", "--allow-empty", "-m", "first")
+	git("commit", "-q", "--allow-empty", "-m", "second")
+
+	sess.RecordGitActivity()
+	if len(sess.Commits) != 2 || sess.Commits[0].Subject != "first" || sess.Commits[1].Subject != "second" {
+		t.Errorf("Commits = %+v, want first and second", sess.Commits)
+	}
+
+	// Ended from another directory, the session's worktree is still used.
+	sess.Worktree = GetRepoRoot()
+	sess.Commits = nil
+	t.Chdir(t.TempDir())
+	sess.RecordGitActivity()
+	if len(sess.Commits) != 2 {
+		t.Errorf("Commits from another directory = %+v, want first and second", sess.Commits)
+	}
+}
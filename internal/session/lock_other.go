@@ -0,0 +1,37 @@
+//go:build !unix
+
+package session
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// lockStale is the age at which a lock file is assumed to be left by a
+// process that died holding it.
+const lockStale = 30 * time.Second
+
+// lockFile takes the lock by creating path exclusively, and waits up to
+// timeout while another process holds it.
+func lockFile(path string, timeout time.Duration) (func(), error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > lockStale {
+			_ = os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for session lock %s", path)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -1,42 +1,90 @@
 package session
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"time"
 )
 
 const SessionDir = ".asana-cli"
+
+// SessionFile is where versions before multiple sessions kept the only one.
 const SessionFile = "session.json"
 
+// DefaultIdleTimeout is how long a session may go without a log before it
+// is paused automatically.
+const DefaultIdleTimeout = time.Hour
+
 type LogEntry struct {
 	Timestamp time.Time `json:"ts"`
 	Type      string    `json:"type"`
 	Text      string    `json:"text"`
 }
 
+// Interval is a span of active work. End is nil while the session is
+// running. Idle marks an interval closed by the idle timeout rather than by
+// 'session pause'.
+type Interval struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+	Idle  bool       `json:"idle,omitempty"`
+}
+
 type Session struct {
-	TaskGID     string     `json:"task_gid"`
-	ProjectGID  string     `json:"project_gid,omitempty"`
-	StartedAt   time.Time  `json:"started_at"`
-	Repo        string     `json:"repo,omitempty"`
-	StartBranch string     `json:"start_branch,omitempty"`
-	Logs        []LogEntry `json:"logs,omitempty"`
-	path        string
+	TaskGID     string    `json:"task_gid"`
+	ProjectGID  string    `json:"project_gid,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+	Repo        string    `json:"repo,omitempty"`
+	StartBranch string    `json:"start_branch,omitempty"`
+	// Worktree is the directory the session was started in.
+	Worktree string `json:"worktree,omitempty"`
+	StartSHA string `json:"start_sha,omitempty"`
+	// Commits and DiffStat cover the work since StartSHA and are filled in
+	// by RecordGitActivity when the session ends.
+	Commits  []Commit `json:"commits,omitempty"`
+	DiffStat string   `json:"diff_stat,omitempty"`
+	// Intervals is empty until the first pause: a session that has never
+	// been paused has run since StartedAt.
+	Intervals []Interval `json:"intervals,omitempty"`
+	Logs      []LogEntry `json:"logs,omitempty"`
+	path      string
 }
 
 func (s *Session) Path() string {
 	return s.path
 }
 
+// Duration is the active time worked: the sum of the session's intervals,
+// with a running interval counted up to now.
 func (s *Session) Duration() time.Duration {
+	return s.ActiveDuration(time.Now())
+}
+
+func (s *Session) ActiveDuration(now time.Time) time.Duration {
+	var total time.Duration
+	for _, iv := range s.intervals() {
+		end := now
+		if iv.End != nil {
+			end = *iv.End
+		}
+		total += end.Sub(iv.Start)
+	}
+	return total
+}
+
+// Elapsed is the wall-clock time since the session started, pauses included.
+func (s *Session) Elapsed() time.Duration {
 	return time.Since(s.StartedAt)
 }
 
 func (s *Session) FormatDuration() string {
-	d := s.Duration()
+	return formatDuration(s.Duration())
+}
+
+func (s *Session) FormatElapsed() string {
+	return formatDuration(s.Elapsed())
+}
+
+func formatDuration(d time.Duration) string {
 	hours := int(d.Hours())
 	minutes := int(d.Minutes()) % 60
 
@@ -46,58 +94,103 @@ func (s *Session) FormatDuration() string {
 	return fmt.Sprintf("%dm", minutes)
 }
 
-func (s *Session) AddLog(logType, text string) {
-	s.Logs = append(s.Logs, LogEntry{
-		Timestamp: time.Now().UTC(),
-		Type:      logType,
-		Text:      text,
-	})
+func (s *Session) intervals() []Interval {
+	if len(s.Intervals) == 0 {
+		return []Interval{{Start: s.StartedAt}}
+	}
+	return s.Intervals
 }
 
-func (s *Session) IsStale() bool {
-	return time.Since(s.StartedAt) > 24*time.Hour
+func (s *Session) Paused() bool {
+	ivs := s.intervals()
+	return ivs[len(ivs)-1].End != nil
 }
 
-func Load(dir string) (*Session, error) {
-	path := filepath.Join(dir, SessionDir, SessionFile)
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
-		return nil, err
+// PausedAt returns when the session was paused, or nil if it is running.
+func (s *Session) PausedAt() *time.Time {
+	ivs := s.intervals()
+	return ivs[len(ivs)-1].End
+}
+
+// Pause closes the running interval at at.
+func (s *Session) Pause(at time.Time) error {
+	return s.pause(at, false)
+}
+
+func (s *Session) pause(at time.Time, idle bool) error {
+	if s.Paused() {
+		return fmt.Errorf("session is already paused")
 	}
+	s.Intervals = s.intervals()
+	last := &s.Intervals[len(s.Intervals)-1]
+	if at.Before(last.Start) {
+		at = last.Start
+	}
+	at = at.UTC()
+	last.End = &at
+	last.Idle = idle
+	return nil
+}
 
-	var s Session
-	if err := json.Unmarshal(data, &s); err != nil {
-		return nil, err
+// Resume starts a new interval at at.
+func (s *Session) Resume(at time.Time) error {
+	if !s.Paused() {
+		return fmt.Errorf("session is not paused")
 	}
-	s.path = path
+	s.Intervals = append(s.Intervals, Interval{Start: at.UTC()})
+	return nil
+}
 
-	return &s, nil
+// PausedForIdle reports whether the session was paused by PauseIfIdle rather
+// than by hand.
+func (s *Session) PausedForIdle() bool {
+	ivs := s.intervals()
+	return ivs[len(ivs)-1].Idle
 }
 
-func (s *Session) Save(dir string) error {
-	sessionDir := filepath.Join(dir, SessionDir)
-	if err := os.MkdirAll(sessionDir, 0755); err != nil {
-		return err
+// PauseIfIdle pauses a running session that has had no log for longer than
+// timeout, ending its active time at the last sign of activity. It reports
+// whether the session was paused. A zero timeout disables it.
+func (s *Session) PauseIfIdle(timeout time.Duration, now time.Time) bool {
+	if timeout <= 0 || s.Paused() {
+		return false
 	}
-	path := filepath.Join(sessionDir, SessionFile)
-	data, err := json.MarshalIndent(s, "", "  ")
-	if err != nil {
-		return err
+	last := s.lastActivity()
+	if now.Sub(last) <= timeout {
+		return false
 	}
-	s.path = path
-	return os.WriteFile(path, data, 0644)
+	return s.pause(last, true) == nil
 }
 
-func Delete(dir string) error {
-	path := filepath.Join(dir, SessionDir, SessionFile)
-	err := os.Remove(path)
-	if os.IsNotExist(err) {
-		return nil
+// lastActivity is the latest log, or the start of the running interval if
+// nothing has been logged since.
+func (s *Session) lastActivity() time.Time {
+	ivs := s.intervals()
+	last := ivs[len(ivs)-1].Start
+	for _, l := range s.Logs {
+		if l.Timestamp.After(last) {
+			last = l.Timestamp
+		}
 	}
-	return err
+	return last
+}
+
+func (s *Session) AddLog(logType, text string) {
+	s.Logs = append(s.Logs, LogEntry{
+		Timestamp: time.Now().UTC(),
+		Type:      logType,
+		Text:      text,
+	})
+}
+
+// IsStale reports whether the session has seen no activity (a log, a resume
+// or a pause) for over a day, however long ago it started.
+func (s *Session) IsStale() bool {
+	last := s.lastActivity()
+	if at := s.PausedAt(); at != nil && at.After(last) {
+		last = *at
+	}
+	return time.Since(last) > 24*time.Hour
 }
 
 func New(taskGID string, opts ...SessionOption) *Session {
@@ -132,11 +225,30 @@ func WithBranch(branch string) SessionOption {
 	}
 }
 
+func WithWorktree(dir string) SessionOption {
+	return func(s *Session) {
+		s.Worktree = dir
+	}
+}
+
+func WithStartSHA(sha string) SessionOption {
+	return func(s *Session) {
+		s.StartSHA = sha
+	}
+}
+
+// maxSummaryCommits caps the commits listed in a summary.
+const maxSummaryCommits = 20
+
 func (s *Session) FormatSummary(endBranch, extraSummary string) string {
 	var result string
 
 	result = "## Work Session\n\n"
-	result += fmt.Sprintf("**Duration:** %s\n", s.FormatDuration())
+	if active, elapsed := s.Duration(), s.Elapsed(); elapsed-active >= time.Minute {
+		result += fmt.Sprintf("**Duration:** %s active (%s elapsed)\n", formatDuration(active), formatDuration(elapsed))
+	} else {
+		result += fmt.Sprintf("**Duration:** %s\n", formatDuration(active))
+	}
 
 	if s.StartBranch != "" {
 		if endBranch != "" && endBranch != s.StartBranch {
@@ -150,6 +262,28 @@ func (s *Session) FormatSummary(endBranch, extraSummary string) string {
 		result += fmt.Sprintf("**Repo:** %s\n", s.Repo)
 	}
 
+	if s.DiffStat != "" {
+		result += fmt.Sprintf("**Changes:** %s\n", s.DiffStat)
+	}
+
+	if len(s.Commits) > 0 {
+		result += "\n### Commits\n"
+		for i, c := range s.Commits {
+			if i == maxSummaryCommits {
+				result += fmt.Sprintf("- …and %d more\n", len(s.Commits)-i)
+				break
+			}
+			short := c.SHA
+			if len(short) > 7 {
+				short = short[:7]
+			}
+			if url := CommitURL(s.Repo, c.SHA); url != "" {
+				short = fmt.Sprintf("[%s](%s)", short, url)
+			}
+			result += fmt.Sprintf("- %s %s\n", short, c.Subject)
+		}
+	}
+
 	progress := s.logsByType("progress")
 	decisions := s.logsByType("decision")
 	blockers := s.logsByType("blocker")
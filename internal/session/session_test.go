@@ -1,8 +1,6 @@
 package session
 
 import (
-	"os"
-	"path/filepath"
 	"testing"
 	"time"
 )
@@ -77,88 +75,40 @@ func TestSessionIsStale(t *testing.T) {
 
 	sess.StartedAt = time.Now().Add(-25 * time.Hour)
 	if !sess.IsStale() {
-		t.Error("session older than 24h should be stale")
+		t.Error("session untouched for over 24h should be stale")
 	}
-}
-
-func TestSessionSaveAndLoad(t *testing.T) {
-	dir := t.TempDir()
-
-	sess := New("12345",
-		WithProject("proj123"),
-		WithBranch("main"),
-	)
-	sess.AddLog("progress", "test log")
-
-	if err := sess.Save(dir); err != nil {
-		t.Fatalf("failed to save session: %v", err)
-	}
-
-	loaded, err := Load(dir)
-	if err != nil {
-		t.Fatalf("failed to load session: %v", err)
-	}
-	if loaded == nil {
-		t.Fatal("expected loaded session to be non-nil")
-	}
-
-	if loaded.TaskGID != "12345" {
-		t.Errorf("expected task_gid 12345, got %s", loaded.TaskGID)
-	}
-	if loaded.ProjectGID != "proj123" {
-		t.Errorf("expected project_gid proj123, got %s", loaded.ProjectGID)
-	}
-	if loaded.StartBranch != "main" {
-		t.Errorf("expected branch main, got %s", loaded.StartBranch)
-	}
-	if len(loaded.Logs) != 1 {
-		t.Errorf("expected 1 log, got %d", len(loaded.Logs))
-	}
-	expectedPath := filepath.Join(dir, SessionDir, SessionFile)
-	if loaded.Path() != expectedPath {
-		t.Errorf("expected path %s, got %s", expectedPath, loaded.Path())
-	}
-}
-
-func TestLoadNonexistent(t *testing.T) {
-	dir := t.TempDir()
 
-	sess, err := Load(dir)
-	if err != nil {
-		t.Fatalf("expected no error for nonexistent session, got %v", err)
-	}
-	if sess != nil {
-		t.Error("expected nil session for nonexistent file")
+	sess.AddLog("progress", "still going")
+	if sess.IsStale() {
+		t.Error("session logged to recently should not be stale")
 	}
 }
 
-func TestDelete(t *testing.T) {
-	dir := t.TempDir()
-
+func TestSessionIsStalePaused(t *testing.T) {
+	now := time.Now()
 	sess := New("12345")
-	if err := sess.Save(dir); err != nil {
-		t.Fatalf("failed to save session: %v", err)
+	sess.StartedAt = now.Add(-72 * time.Hour)
+	if err := sess.Pause(now.Add(-2 * time.Hour)); err != nil {
+		t.Fatal(err)
 	}
-
-	path := filepath.Join(dir, SessionDir, SessionFile)
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		t.Fatal("session file should exist after save")
+	if sess.IsStale() {
+		t.Error("session paused 2h ago should not be stale")
 	}
 
-	if err := Delete(dir); err != nil {
-		t.Fatalf("failed to delete session: %v", err)
+	idle := New("12345")
+	idle.StartedAt = now.Add(-72 * time.Hour)
+	idle.Logs = []LogEntry{{Timestamp: now.Add(-48 * time.Hour), Type: "progress", Text: "last"}}
+	if !idle.PauseIfIdle(time.Hour, now) {
+		t.Fatal("expected the idle session to be paused")
 	}
-
-	if _, err := os.Stat(path); !os.IsNotExist(err) {
-		t.Error("session file should not exist after delete")
+	if !idle.IsStale() {
+		t.Error("session idle-paused at a log 48h ago should be stale")
 	}
-}
-
-func TestDeleteNonexistent(t *testing.T) {
-	dir := t.TempDir()
-
-	if err := Delete(dir); err != nil {
-		t.Errorf("delete of nonexistent should not error, got %v", err)
+	if err := idle.Resume(now); err != nil {
+		t.Fatal(err)
+	}
+	if idle.IsStale() {
+		t.Error("resumed session should not be stale")
 	}
 }
 
@@ -224,6 +174,74 @@ func TestFormatSummaryBranchChange(t *testing.T) {
 	}
 }
 
+func TestFormatSummaryGitActivity(t *testing.T) {
+	sess := New("12345", WithRepo("github.com/org/repo"))
+	sess.Commits = []Commit{{SHA: "0123456789abcdef", Subject: "Add parser"}}
+	sess.DiffStat = "2 files changed, 10 insertions(+)"
+
+	summary := sess.FormatSummary("", "")
+
+	expected := []string{
+		"**Changes:** 2 files changed, 10 insertions(+)",
+		"### Commits",
+		"- [0123456](https://github.com/org/repo/commit/0123456789abcdef) Add parser",
+	}
+	for _, exp := range expected {
+		if !containsString(summary, exp) {
+			t.Errorf("expected summary to contain '%s'", exp)
+		}
+	}
+}
+
+func TestPauseResume(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	sess := New("12345")
+	sess.StartedAt = start
+
+	if err := sess.Resume(start); err == nil {
+		t.Error("Resume() of a running session should fail")
+	}
+	if err := sess.Pause(start.Add(30 * time.Minute)); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if !sess.Paused() || sess.PausedForIdle() {
+		t.Error("expected a session paused by hand")
+	}
+	if err := sess.Pause(start.Add(40 * time.Minute)); err == nil {
+		t.Error("Pause() of a paused session should fail")
+	}
+	if err := sess.Resume(start.Add(2 * time.Hour)); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+
+	if got := sess.ActiveDuration(start.Add(150 * time.Minute)); got != time.Hour {
+		t.Errorf("ActiveDuration() = %v, want 1h", got)
+	}
+}
+
+func TestPauseIfIdle(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	sess := New("12345")
+	sess.StartedAt = start
+	sess.Logs = []LogEntry{{Timestamp: start.Add(20 * time.Minute), Type: "progress", Text: "A"}}
+
+	if sess.PauseIfIdle(time.Hour, start.Add(time.Hour)) {
+		t.Error("PauseIfIdle() paused before the timeout")
+	}
+	if sess.PauseIfIdle(0, start.Add(5*time.Hour)) {
+		t.Error("PauseIfIdle() with a zero timeout should never pause")
+	}
+	if !sess.PauseIfIdle(time.Hour, start.Add(5*time.Hour)) {
+		t.Fatal("PauseIfIdle() did not pause an idle session")
+	}
+	if !sess.PausedForIdle() {
+		t.Error("PausedForIdle() = false")
+	}
+	if got := sess.ActiveDuration(start.Add(5 * time.Hour)); got != 20*time.Minute {
+		t.Errorf("ActiveDuration() = %v, want active time to end at the last log", got)
+	}
+}
+
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsSubstring(s, substr))
 }
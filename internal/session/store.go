@@ -0,0 +1,375 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SessionsDir holds one file per session, named after its task GID.
+const SessionsDir = "sessions"
+
+// CurrentFile names the current session of a worktree. In a git repo it
+// lives in the worktree's git dir, so each worktree has its own.
+const CurrentFile = "asana-cli-session"
+
+// lockTimeout bounds how long a writer waits for another to finish.
+const lockTimeout = 5 * time.Second
+
+// Store holds the sessions of one repo (or of the user, outside a repo) and
+// tracks which one is current in the calling worktree.
+type Store struct {
+	dir     string
+	current string
+}
+
+// NewStore returns the store under root's .asana-cli directory. current is
+// the file naming the current session; if empty it is kept alongside the
+// sessions.
+func NewStore(root, current string) *Store {
+	dir := filepath.Join(root, SessionDir)
+	if current == "" {
+		current = filepath.Join(dir, CurrentFile)
+	}
+	return &Store{dir: dir, current: current}
+}
+
+func (s *Store) path(taskGID string) string {
+	return filepath.Join(s.dir, SessionsDir, taskGID+".json")
+}
+
+// journal is where logs are appended between full writes of the session.
+func (s *Store) journal(taskGID string) string {
+	return filepath.Join(s.dir, SessionsDir, taskGID+".log.jsonl")
+}
+
+func validID(taskGID string) error {
+	if taskGID == "" || strings.ContainsAny(taskGID, `/\`) || strings.HasPrefix(taskGID, ".") {
+		return fmt.Errorf("invalid session id %q", taskGID)
+	}
+	return nil
+}
+
+// Get returns the session for taskGID, or nil if there is none.
+func (s *Store) Get(taskGID string) (*Session, error) {
+	if err := validID(taskGID); err != nil {
+		return nil, err
+	}
+	if err := s.migrate(); err != nil {
+		return nil, err
+	}
+	return s.read(taskGID)
+}
+
+// Current returns the worktree's current session, or nil if it has none or
+// the session has ended.
+func (s *Store) Current() (*Session, error) {
+	if err := s.migrate(); err != nil {
+		return nil, err
+	}
+	gid := s.CurrentGID()
+	if gid == "" {
+		return nil, nil
+	}
+	return s.Get(gid)
+}
+
+// CurrentGID returns the task GID of the worktree's current session, or ""
+// if none is set.
+func (s *Store) CurrentGID() string {
+	data, err := os.ReadFile(s.current)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// SetCurrent makes taskGID the worktree's current session.
+func (s *Store) SetCurrent(taskGID string) error {
+	if err := validID(taskGID); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.current), 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(s.current, []byte(taskGID+"\n"))
+}
+
+// List returns every session in the store, oldest first.
+func (s *Store) List() ([]*Session, error) {
+	if err := s.migrate(); err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(s.dir, SessionsDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var sessions []*Session
+	for _, p := range paths {
+		sess, err := s.read(strings.TrimSuffix(filepath.Base(p), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		if sess != nil {
+			sessions = append(sessions, sess)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+	return sessions, nil
+}
+
+// Save writes sess under the store's lock.
+func (s *Store) Save(sess *Session) error {
+	if err := validID(sess.TaskGID); err != nil {
+		return err
+	}
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return s.write(sess)
+}
+
+// Update loads the session for taskGID, applies fn and saves the result,
+// holding the store's lock throughout so concurrent updates are not lost.
+// Nothing is saved if fn fails.
+func (s *Store) Update(taskGID string, fn func(*Session) error) (*Session, error) {
+	if err := validID(taskGID); err != nil {
+		return nil, err
+	}
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	sess, err := s.read(taskGID)
+	if err != nil {
+		return nil, err
+	}
+	if sess == nil {
+		return nil, fmt.Errorf("no session for task %s", taskGID)
+	}
+	if err := fn(sess); err != nil {
+		return nil, err
+	}
+	if err := s.write(sess); err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
+// AppendLog adds a log entry to the session's journal. Unlike Update it
+// doesn't rewrite the session, so a crash can at worst lose the entry being
+// written.
+func (s *Store) AppendLog(taskGID, logType, text string) (*Session, error) {
+	if err := validID(taskGID); err != nil {
+		return nil, err
+	}
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	sess, err := s.read(taskGID)
+	if err != nil {
+		return nil, err
+	}
+	if sess == nil {
+		return nil, fmt.Errorf("no session for task %s", taskGID)
+	}
+
+	sess.AddLog(logType, text)
+	data, err := json.Marshal(sess.Logs[len(sess.Logs)-1])
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(s.journal(taskGID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
+// Compact folds the session's journal into its file.
+func (s *Store) Compact(taskGID string) error {
+	_, err := s.Update(taskGID, func(*Session) error { return nil })
+	return err
+}
+
+// Delete removes the session for taskGID, and clears the worktree's current
+// session if it was this one.
+func (s *Store) Delete(taskGID string) error {
+	if err := validID(taskGID); err != nil {
+		return err
+	}
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return s.remove(taskGID)
+}
+
+// remove deletes the session's files. It must be called with the lock held.
+func (s *Store) remove(taskGID string) error {
+	for _, path := range []string{s.path(taskGID), s.journal(taskGID)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if s.CurrentGID() == taskGID {
+		if err := os.Remove(s.current); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// write saves sess in full, journal included, then drops the journal. It
+// must be called with the lock held.
+func (s *Store) write(sess *Session) error {
+	path := s.path(sess.TaskGID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(sess, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return err
+	}
+	sess.path = path
+	// Entries left in the journal by a crash here are skipped on read, as
+	// they are already in the file.
+	if err := os.Remove(s.journal(sess.TaskGID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// lock takes the store's lock, waiting while another process holds it.
+func (s *Store) lock() (func(), error) {
+	dir := filepath.Join(s.dir, SessionsDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return lockFile(filepath.Join(dir, ".lock"), lockTimeout)
+}
+
+// migrate moves a session saved by older versions, as a single session.json,
+// into the store and makes it current.
+func (s *Store) migrate() error {
+	legacy := filepath.Join(s.dir, SessionFile)
+	sess, err := readSession(legacy)
+	if err != nil || sess == nil || validID(sess.TaskGID) != nil {
+		return err
+	}
+	if existing, err := s.read(sess.TaskGID); err != nil {
+		return err
+	} else if existing == nil {
+		if err := s.Save(sess); err != nil {
+			return err
+		}
+	}
+	if s.CurrentGID() == "" {
+		if err := s.SetCurrent(sess.TaskGID); err != nil {
+			return err
+		}
+	}
+	return os.Remove(legacy)
+}
+
+// read loads the session for taskGID with the entries from its journal.
+func (s *Store) read(taskGID string) (*Session, error) {
+	sess, err := readSession(s.path(taskGID))
+	if err != nil || sess == nil {
+		return sess, err
+	}
+
+	data, err := os.ReadFile(s.journal(taskGID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return sess, nil
+		}
+		return nil, err
+	}
+	var journaled []LogEntry
+	for _, line := range strings.Split(string(data), "\n") {
+		var l LogEntry
+		// A line torn by a crash mid-append is dropped.
+		if line == "" || json.Unmarshal([]byte(line), &l) != nil {
+			continue
+		}
+		journaled = append(journaled, l)
+	}
+	sess.Logs = mergeLogs(sess.Logs, journaled)
+	return sess, nil
+}
+
+func readSession(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	s.path = path
+
+	return &s, nil
+}
+
+// writeFileAtomic replaces path with data via a synced temp file and a
+// rename, so readers see either the old or the new contents.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
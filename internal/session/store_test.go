@@ -0,0 +1,261 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStoreSaveAndGet(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir, "")
+
+	sess := New("12345",
+		WithProject("proj123"),
+		WithBranch("main"),
+	)
+	sess.AddLog("progress", "test log")
+
+	if err := store.Save(sess); err != nil {
+		t.Fatalf("failed to save session: %v", err)
+	}
+
+	loaded, err := store.Get("12345")
+	if err != nil {
+		t.Fatalf("failed to load session: %v", err)
+	}
+	if loaded == nil {
+		t.Fatal("expected loaded session to be non-nil")
+	}
+
+	if loaded.ProjectGID != "proj123" {
+		t.Errorf("expected project_gid proj123, got %s", loaded.ProjectGID)
+	}
+	if loaded.StartBranch != "main" {
+		t.Errorf("expected branch main, got %s", loaded.StartBranch)
+	}
+	if len(loaded.Logs) != 1 {
+		t.Errorf("expected 1 log, got %d", len(loaded.Logs))
+	}
+	expectedPath := filepath.Join(dir, SessionDir, SessionsDir, "12345.json")
+	if loaded.Path() != expectedPath {
+		t.Errorf("expected path %s, got %s", expectedPath, loaded.Path())
+	}
+
+	if _, err := store.Get("../12345"); err == nil {
+		t.Error("Get() should reject ids that are not a file name")
+	}
+}
+
+func TestStoreGetNonexistent(t *testing.T) {
+	store := NewStore(t.TempDir(), "")
+
+	sess, err := store.Get("12345")
+	if err != nil {
+		t.Fatalf("expected no error for nonexistent session, got %v", err)
+	}
+	if sess != nil {
+		t.Error("expected nil session for nonexistent file")
+	}
+
+	sess, err = store.Current()
+	if err != nil || sess != nil {
+		t.Errorf("Current() = %v, %v, want nil, nil", sess, err)
+	}
+}
+
+func TestStoreCurrentPerWorktree(t *testing.T) {
+	dir := t.TempDir()
+	a := NewStore(dir, filepath.Join(dir, "a", CurrentFile))
+	b := NewStore(dir, filepath.Join(dir, "b", CurrentFile))
+
+	for _, gid := range []string{"1", "2"} {
+		if err := a.Save(New(gid)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := a.SetCurrent("1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetCurrent("2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if sess, _ := a.Current(); sess == nil || sess.TaskGID != "1" {
+		t.Errorf("a.Current() = %+v, want task 1", sess)
+	}
+	if sess, _ := b.Current(); sess == nil || sess.TaskGID != "2" {
+		t.Errorf("b.Current() = %+v, want task 2", sess)
+	}
+
+	sessions, err := b.List()
+	if err != nil || len(sessions) != 2 {
+		t.Fatalf("List() = %d sessions, %v, want 2", len(sessions), err)
+	}
+}
+
+func TestStoreDelete(t *testing.T) {
+	store := NewStore(t.TempDir(), "")
+
+	if err := store.Save(New("12345")); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetCurrent("12345"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Delete("12345"); err != nil {
+		t.Fatalf("failed to delete session: %v", err)
+	}
+	if sess, _ := store.Get("12345"); sess != nil {
+		t.Error("session should not exist after delete")
+	}
+	if gid := store.CurrentGID(); gid != "" {
+		t.Errorf("CurrentGID() = %q after delete, want empty", gid)
+	}
+
+	if err := store.Delete("12345"); err != nil {
+		t.Errorf("delete of nonexistent should not error, got %v", err)
+	}
+}
+
+func TestStoreUpdateConcurrent(t *testing.T) {
+	store := NewStore(t.TempDir(), "")
+	if err := store.Save(New("12345")); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := store.Update("12345", func(s *Session) error {
+				s.AddLog("progress", "step")
+				return nil
+			}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	sess, err := store.Get("12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sess.Logs) != 20 {
+		t.Errorf("got %d logs, want 20", len(sess.Logs))
+	}
+}
+
+func TestStorePauseSaveAndLoad(t *testing.T) {
+	store := NewStore(t.TempDir(), "")
+	sess := New("12345")
+	sess.StartedAt = time.Now().Add(-time.Hour)
+	if err := sess.Pause(time.Now().Add(-30 * time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(sess); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.Get("12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Paused() || loaded.FormatDuration() != "30m" {
+		t.Errorf("loaded session paused = %v, duration = %s, want paused at 30m", loaded.Paused(), loaded.FormatDuration())
+	}
+}
+
+func TestStoreMigratesLegacySession(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, SessionDir, SessionFile)
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte(`{"task_gid": "12345", "started_at": "2024-01-01T00:00:00Z"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewStore(dir, "")
+	sess, err := store.Current()
+	if err != nil || sess == nil || sess.TaskGID != "12345" {
+		t.Fatalf("Current() = %+v, %v, want the legacy session", sess, err)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Error("legacy session file should be removed after migration")
+	}
+}
+
+func TestStoreAppendLogJournal(t *testing.T) {
+	store := NewStore(t.TempDir(), "")
+	if err := store.Save(New("12345")); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := store.AppendLog("12345", "progress", "step"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// A torn line from a crash mid-append is ignored.
+	f, err := os.OpenFile(store.journal("12345"), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"ts": "2024-01-01T00:`)
+	_ = f.Close()
+
+	sess, err := store.Get("12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sess.Logs) != 20 {
+		t.Fatalf("got %d logs, want 20", len(sess.Logs))
+	}
+
+	if err := store.Compact("12345"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(store.journal("12345")); !os.IsNotExist(err) {
+		t.Error("journal should be removed after compaction")
+	}
+	if sess, _ := store.Get("12345"); len(sess.Logs) != 20 {
+		t.Errorf("got %d logs after compaction, want 20", len(sess.Logs))
+	}
+}
+
+func TestStoreJournalSkipsCompactedEntries(t *testing.T) {
+	store := NewStore(t.TempDir(), "")
+	sess := New("12345")
+	sess.AddLog("progress", "kept")
+	if err := store.Save(sess); err != nil {
+		t.Fatal(err)
+	}
+
+	// As if a crash stopped compaction before the journal was removed.
+	data, _ := json.Marshal(sess.Logs[0])
+	if err := os.WriteFile(store.journal("12345"), append(data, '\n'), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.Get("12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Logs) != 1 {
+		t.Errorf("got %d logs, want the compacted entry once", len(loaded.Logs))
+	}
+}
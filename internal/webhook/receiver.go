@@ -0,0 +1,186 @@
+// Package webhook implements the receiving side of Asana webhooks: the
+// X-Hook-Secret handshake and X-Hook-Signature verification.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+const (
+	HeaderSecret    = "X-Hook-Secret"
+	HeaderSignature = "X-Hook-Signature"
+
+	maxBodyBytes = 10 << 20
+)
+
+// SecretStore keeps the secrets handed out during handshakes. One receiver
+// can serve several webhooks, each with its own secret. If path is set the
+// secrets are persisted so a restarted receiver keeps verifying.
+type SecretStore struct {
+	mu      sync.Mutex
+	path    string
+	secrets []string
+}
+
+func NewSecretStore(path string) (*SecretStore, error) {
+	s := &SecretStore{path: path}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.secrets); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add stores secret. Unless allowNew is set, a secret is only added to an
+// empty store; a known secret is always accepted. It reports whether secret
+// is now stored.
+func (s *SecretStore) Add(secret string, allowNew bool) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.secrets {
+		if existing == secret {
+			return true, nil
+		}
+	}
+	if !allowNew && len(s.secrets) > 0 {
+		return false, nil
+	}
+	s.secrets = append(s.secrets, secret)
+
+	if s.path == "" {
+		return true, nil
+	}
+	data, err := json.Marshal(s.secrets)
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(s.path, data, 0o600)
+}
+
+// Verify reports whether signature is the hex HMAC-SHA256 of body under any
+// known secret.
+func (s *SecretStore) Verify(body []byte, signature string) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil || len(got) == 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, secret := range s.secrets {
+		if hmac.Equal(got, Sign([]byte(secret), body)) {
+			return true
+		}
+	}
+	return false
+}
+
+func Sign(secret, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// Handler answers handshakes and passes verified event deliveries to
+// OnEvents. Heartbeats (empty event lists) are acknowledged and dropped.
+// OnEvents runs before the response is sent, so it should only enqueue work:
+// Asana retries deliveries that take more than a few seconds.
+//
+// Anyone who can reach the receiver can attempt a handshake, and whoever
+// stores a secret can sign deliveries. So a handshake with a new secret is
+// only accepted while no secret is stored, or while AcceptHandshake is set.
+type Handler struct {
+	Secrets *SecretStore
+	// AcceptHandshake allows secrets for additional webhooks to be stored.
+	AcceptHandshake bool
+	OnEvents        func(events []models.Event)
+	// OnError reports rejected or malformed deliveries; it may be nil.
+	OnError func(err error)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if secret := r.Header.Get(HeaderSecret); secret != "" {
+		ok, err := h.Secrets.Add(secret, h.AcceptHandshake)
+		if err != nil {
+			h.reportError(err)
+			http.Error(w, "failed to store secret", http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			h.reportError(errUnexpectedHandshake)
+			http.Error(w, "handshake not accepted", http.StatusForbidden)
+			return
+		}
+		w.Header().Set(HeaderSecret, secret)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if !h.Secrets.Verify(body, r.Header.Get(HeaderSignature)) {
+		h.reportError(errInvalidSignature)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var payload struct {
+		Events []models.Event `json:"events"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		h.reportError(err)
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if len(payload.Events) > 0 && h.OnEvents != nil {
+		h.OnEvents(payload.Events)
+	}
+}
+
+func (h *Handler) reportError(err error) {
+	if h.OnError != nil {
+		h.OnError(err)
+	}
+}
+
+var (
+	errInvalidSignature    = errors.New("rejected delivery with invalid X-Hook-Signature")
+	errUnexpectedHandshake = errors.New("rejected handshake with a new X-Hook-Secret; restart with --accept-handshake to register another webhook")
+)
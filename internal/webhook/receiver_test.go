@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/whoaa512/asana-cli/internal/models"
+)
+
+// sender stands in for Asana: it performs the handshake and signs deliveries
+// with the secret it handed out.
+type sender struct {
+	t      *testing.T
+	url    string
+	secret string
+}
+
+func (s *sender) handshake() {
+	s.t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, s.url, nil)
+	req.Header.Set(HeaderSecret, s.secret)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		s.t.Fatalf("handshake status = %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get(HeaderSecret); got != s.secret {
+		s.t.Fatalf("handshake echoed %q, want %q", got, s.secret)
+	}
+}
+
+func (s *sender) deliver(body string, signWith string) int {
+	s.t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, s.url, bytes.NewBufferString(body))
+	req.Header.Set(HeaderSignature, hex.EncodeToString(Sign([]byte(signWith), []byte(body))))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestHandlerHandshakeAndDelivery(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secrets.json")
+	secrets, err := NewSecretStore(secretFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var received []models.Event
+	server := httptest.NewServer(&Handler{
+		Secrets:  secrets,
+		OnEvents: func(events []models.Event) { received = append(received, events...) },
+	})
+	defer server.Close()
+
+	s := &sender{t: t, url: server.URL, secret: "shh"}
+	s.handshake()
+
+	body := `{"events":[{"action":"added","resource":{"gid":"101","resource_type":"task"},"parent":{"gid":"55","resource_type":"section"}}]}`
+	if status := s.deliver(body, "shh"); status != http.StatusOK {
+		t.Fatalf("signed delivery status = %d, want 200", status)
+	}
+	if len(received) != 1 || received[0].Resource.GID != "101" || received[0].Parent.GID != "55" {
+		t.Errorf("received = %+v", received)
+	}
+
+	if status := s.deliver(body, "wrong"); status != http.StatusUnauthorized {
+		t.Errorf("forged delivery status = %d, want 401", status)
+	}
+	if status := s.deliver(`{"events":[]}`, "shh"); status != http.StatusOK {
+		t.Errorf("heartbeat status = %d, want 200", status)
+	}
+	if len(received) != 1 {
+		t.Errorf("received %d events, want 1", len(received))
+	}
+
+	reloaded, err := NewSecretStore(secretFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reloaded.Verify([]byte(body), hex.EncodeToString(Sign([]byte("shh"), []byte(body)))) {
+		t.Error("persisted secret did not verify after reload")
+	}
+}
+
+func TestHandlerRejectsUnexpectedHandshake(t *testing.T) {
+	secrets, err := NewSecretStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := &Handler{Secrets: secrets}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	(&sender{t: t, url: server.URL, secret: "first"}).handshake()
+	// Asana repeating a known handshake is fine.
+	(&sender{t: t, url: server.URL, secret: "first"}).handshake()
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+	req.Header.Set(HeaderSecret, "attacker")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("second handshake status = %d, want 403", resp.StatusCode)
+	}
+
+	body := `{"events":[{"action":"added"}]}`
+	forged := &sender{t: t, url: server.URL}
+	if status := forged.deliver(body, "attacker"); status != http.StatusUnauthorized {
+		t.Errorf("delivery signed with rejected secret status = %d, want 401", status)
+	}
+
+	handler.AcceptHandshake = true
+	(&sender{t: t, url: server.URL, secret: "second"}).handshake()
+}